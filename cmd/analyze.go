@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/filesystem"
 	"miniReviewer/internal/git"
 	"miniReviewer/internal/types"
 
@@ -16,13 +18,16 @@ import (
 
 // AnalyzeCmd команда для анализа кода
 func AnalyzeCmd() *cobra.Command {
-	var from, to, commit, output string
+	var from, to, commit, output, author string
 	var ignore []string
 	var last bool
 	var commits []string
 	var unstaged bool
 	var staged bool
 	var mr bool
+	var target, failOn string
+	var timeBudget time.Duration
+	workingTree := git.WorkingTreeOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "analyze",
@@ -33,11 +38,14 @@ func AnalyzeCmd() *cobra.Command {
 - Конкретные коммиты по хешам (--commits)
 - Диапазон коммитов (--from --to)
 - Незакоммиченные изменения (--unstaged, --staged)
-- Merge Request (--mr)
+- Merge Request (--mr, --target): изменения ветки с момента ответвления от целевой
+
+Без флагов анализируются все изменения рабочей копии: подготовленные,
+неподготовленные и новые неотслеживаемые файлы (--include-* отключают категории).
 
 Типы проверок настраиваются в конфигурации.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runAnalysis(from, to, output, ignore, last, commits, unstaged, staged, mr)
+			runAnalysis(from, to, output, author, target, failOn, timeBudget, ignore, last, commits, unstaged, staged, mr, workingTree)
 		},
 	}
 
@@ -48,27 +56,58 @@ func AnalyzeCmd() *cobra.Command {
 	cmd.Flags().StringVar(&commit, "commit", "", "анализ конкретного коммита (устарело, используйте --commits)")
 	cmd.Flags().BoolVar(&unstaged, "unstaged", false, "анализ незакоммиченных изменений")
 	cmd.Flags().BoolVar(&staged, "staged", false, "анализ подготовленных к коммиту изменений")
-	cmd.Flags().BoolVar(&mr, "mr", false, "анализ Merge Request (сравнение с основной веткой)")
+	cmd.Flags().BoolVar(&mr, "mr", false, "анализ Merge Request (изменения относительно merge-base с основной веткой)")
+	cmd.Flags().StringVar(&target, "target", "", "целевая ветка Merge Request, например origin/develop (включает --mr)")
+	cmd.Flags().BoolVar(&workingTree.Staged, "include-staged", true, "включать подготовленные изменения в анализ рабочей копии")
+	cmd.Flags().BoolVar(&workingTree.Unstaged, "include-unstaged", true, "включать неподготовленные изменения в анализ рабочей копии")
+	cmd.Flags().BoolVar(&workingTree.Untracked, "include-untracked", true, "включать неотслеживаемые файлы в анализ рабочей копии")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
 	cmd.Flags().StringArrayVar(&ignore, "ignore", []string{}, "паттерны для игнорирования")
+	cmd.Flags().StringVar(&author, "author", "", "оставить только проблемы в строках автора (имя или email, по git blame)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "завершиться с кодом 1, если найдены проблемы этой важности или выше (low, medium, high, critical)")
+	cmd.Flags().DurationVar(&timeBudget, "time-budget", 0, "максимальное время анализа; по истечении проверка пропускается с кодом 0")
 
 	return cmd
 }
 
 // runAnalysis выполняет анализ изменений
-func runAnalysis(from, to, output string, ignore []string, last bool, commits []string, unstaged, staged, mr bool) {
+func runAnalysis(from, to, output, author, target, failOn string, timeBudget time.Duration, ignore []string, last bool, commits []string, unstaged, staged, mr bool, workingTree git.WorkingTreeOptions) {
 	verbose := viper.GetBool("verbose")
 
+	if failOn != "" && analyzer.SeverityRank(failOn) == 0 {
+		fmt.Printf("❌ Неизвестная важность для --fail-on: %s (low, medium, high, critical)\n", failOn)
+		os.Exit(1)
+	}
+
+	// Долгий анализ не должен блокировать коммит или push: по истечении
+	// бюджета времени проверка пропускается
+	var budget *time.Timer
+	if timeBudget > 0 {
+		budget = time.AfterFunc(timeBudget, func() {
+			fmt.Printf("\n⏱️  Время анализа (%s) истекло, проверка пропущена\n", timeBudget)
+			os.Exit(0)
+		})
+	}
+
 	printAnalysisHeader(verbose)
 
 	// Проверяем git репозиторий
 	gitClient := validateGitRepository(verbose)
 
+	var attributor *analyzer.Attributor
+	if viper.GetBool("git.enable_blame") {
+		attributor = analyzer.NewAttributor(gitClient)
+	}
+	if err := validateAuthorFilter(author, attributor); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
 	// Определяем тип анализа
-	analysisType := determineAnalysisType(last, commits, from, to, unstaged, staged, mr)
+	analysisType := determineAnalysisType(last, commits, from, to, unstaged, staged, mr || target != "")
 
 	// Получаем изменения для анализа
-	changes := getChangesForAnalysis(gitClient, analysisType, from, to, commits, unstaged, staged, mr, verbose)
+	changes := getChangesForAnalysis(gitClient, analysisType, from, to, target, commits, workingTree, verbose)
 
 	if len(changes) == 0 {
 		fmt.Println("✅ Нет изменений для анализа")
@@ -76,19 +115,55 @@ func runAnalysis(from, to, output string, ignore []string, last bool, commits []
 	}
 
 	// Выполняем анализ
-	results := performAnalysis(changes, verbose)
+	ignorePatterns := append(viper.GetStringSlice("analysis.ignore_patterns"), ignore...)
+	results := performAnalysis(gitClient, attributor, changes, ignorePatterns, selectedModules(verbose), verbose)
+
+	// Бюджет времени ограничивает только анализ: вывод, сохранение результатов и
+	// проверка --fail-on не должны прерываться
+	if budget != nil && !budget.Stop() {
+		// Таймер уже сработал и завершает процесс с кодом 0
+		select {}
+	}
+
+	if author != "" {
+		analyzer.FilterResultsByAuthor(results, author)
+		fmt.Printf("👤 Фильтр по автору: %s\n", author)
+	}
 
 	// Выводим результаты
 	printAnalysisResults(results, analysisType, verbose)
+	analyzer.PrintModuleStatistics(results, workspaceModules)
+	analyzer.PrintAuthorStatistics(results)
 
 	// Сохраняем результаты если указан файл
 	if output != "" {
 		saveAnalysisResults(results, output, verbose)
 	}
 
+	if failOn != "" {
+		if blocking := countIssuesAtLeast(results, failOn); blocking > 0 {
+			fmt.Printf("\n❌ Найдено проблем с важностью %s и выше: %d\n", failOn, blocking)
+			os.Exit(1)
+		}
+	}
+
 	fmt.Println("\n✅ Анализ завершен")
 }
 
+// countIssuesAtLeast считает проблемы с важностью не ниже severity
+func countIssuesAtLeast(results []*types.CodeAnalysisResult, severity string) int {
+	minRank := analyzer.SeverityRank(severity)
+	count := 0
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if analyzer.SeverityRank(issue.Severity) >= minRank {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // printAnalysisHeader выводит заголовок анализа
 func printAnalysisHeader(verbose bool) {
 	fmt.Println("🚀 Запуск AI-анализа...")
@@ -115,7 +190,7 @@ func validateGitRepository(verbose bool) *git.Client {
 		fmt.Println("🔍 Проверяю git репозиторий...")
 	}
 
-	gitClient := git.NewClient()
+	gitClient := git.NewClient("")
 	if !gitClient.IsRepository() {
 		fmt.Println("❌ Git репозиторий не найден. Убедитесь, что вы находитесь в git репозитории.")
 		os.Exit(1)
@@ -170,10 +245,12 @@ type ChangeInfo struct {
 	Identifier  string
 	Diff        string
 	Description string
+	Revision    string // ревизия с новой версией файлов; пустая строка - индекс git
+	WorkingTree bool   // новая версия файлов находится в рабочей копии
 }
 
 // getChangesForAnalysis получает изменения для анализа
-func getChangesForAnalysis(gitClient *git.Client, analysisType AnalysisType, from, to string, commits []string, unstaged, staged, mr bool, verbose bool) []ChangeInfo {
+func getChangesForAnalysis(gitClient *git.Client, analysisType AnalysisType, from, to, target string, commits []string, workingTree git.WorkingTreeOptions, verbose bool) []ChangeInfo {
 	var changes []ChangeInfo
 
 	if verbose {
@@ -192,9 +269,9 @@ func getChangesForAnalysis(gitClient *git.Client, analysisType AnalysisType, fro
 	case AnalysisStaged:
 		changes = getStagedChanges(gitClient, verbose)
 	case AnalysisMR:
-		changes = getMRChanges(gitClient, verbose)
+		changes = getMRChanges(gitClient, target, verbose)
 	case AnalysisCurrent:
-		changes = getCurrentChanges(gitClient, verbose)
+		changes = getCurrentChanges(gitClient, workingTree, verbose)
 	}
 
 	if verbose {
@@ -214,7 +291,7 @@ func getLastCommitChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
 
 	fmt.Printf("Анализ последнего коммита: %s\n", lastCommit)
 
-	diff, err := gitClient.GetDiff(lastCommit, lastCommit+"~1")
+	diff, err := gitClient.GetCommitDiff(lastCommit)
 	if err != nil {
 		fmt.Printf("❌ Ошибка получения diff: %v\n", err)
 		os.Exit(1)
@@ -225,6 +302,7 @@ func getLastCommitChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
 		Identifier:  lastCommit,
 		Diff:        diff,
 		Description: fmt.Sprintf("Последний коммит: %s", lastCommit),
+		Revision:    lastCommit,
 	}}
 }
 
@@ -235,7 +313,7 @@ func getSpecificCommitsChanges(gitClient *git.Client, commits []string, verbose
 	for _, commit := range commits {
 		fmt.Printf("Анализ коммита: %s\n", commit)
 
-		diff, err := gitClient.GetDiff(commit, commit+"~1")
+		diff, err := gitClient.GetCommitDiff(commit)
 		if err != nil {
 			fmt.Printf("⚠️  Ошибка получения diff для коммита %s: %v\n", commit, err)
 			continue
@@ -246,6 +324,7 @@ func getSpecificCommitsChanges(gitClient *git.Client, commits []string, verbose
 			Identifier:  commit,
 			Diff:        diff,
 			Description: fmt.Sprintf("Коммит: %s", commit),
+			Revision:    commit,
 		})
 	}
 
@@ -267,6 +346,7 @@ func getRangeChanges(gitClient *git.Client, from, to string, verbose bool) []Cha
 		Identifier:  fmt.Sprintf("%s..%s", from, to),
 		Diff:        diff,
 		Description: fmt.Sprintf("Диапазон: %s..%s", from, to),
+		Revision:    to,
 	}}
 }
 
@@ -285,6 +365,7 @@ func getUnstagedChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
 		Identifier:  "unstaged",
 		Diff:        diff,
 		Description: "Незакоммиченные изменения",
+		WorkingTree: true,
 	}}
 }
 
@@ -306,25 +387,39 @@ func getStagedChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
 	}}
 }
 
-// getMRChanges получает изменения для Merge Request
-func getMRChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
+// getMRChanges получает изменения Merge Request: diff от merge-base с целевой
+// веткой до HEAD (как git diff target...HEAD), поэтому изменения, попавшие в
+// целевую ветку после ответвления, не выглядят отмененными
+func getMRChanges(gitClient *git.Client, target string, verbose bool) []ChangeInfo {
 	fmt.Println("Анализ Merge Request")
 
-	// Получаем основную ветку (обычно main или master)
-	mainBranch := gitClient.GetMainBranch()
-	if mainBranch == "" {
-		mainBranch = "main"
-	}
+	target = resolveMRTarget(gitClient, target)
 
 	currentBranch, err := gitClient.GetCurrentBranch()
 	if err != nil {
 		fmt.Printf("❌ Ошибка получения текущей ветки: %v\n", err)
 		os.Exit(1)
 	}
+	currentBranch = strings.TrimSpace(currentBranch)
+	if currentBranch == "" {
+		currentBranch = "HEAD"
+	}
+
+	mergeBase, err := gitClient.GetMergeBase(target, "HEAD")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Сравнение ветки %s с %s (merge-base %s)\n", currentBranch, target, shortCommitHash(mergeBase))
 
-	fmt.Printf("Сравнение ветки %s с %s\n", currentBranch, mainBranch)
+	mrCommits, err := gitClient.GetCommits(target+"..HEAD", 0, false)
+	if err != nil {
+		fmt.Printf("⚠️  Не удалось получить список коммитов MR: %v\n", err)
+	}
+	printMRCommits(mrCommits)
 
-	diff, err := gitClient.GetDiff(mainBranch, currentBranch)
+	diff, err := gitClient.GetDiff(mergeBase, "HEAD")
 	if err != nil {
 		fmt.Printf("❌ Ошибка получения diff для MR: %v\n", err)
 		os.Exit(1)
@@ -332,33 +427,90 @@ func getMRChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
 
 	return []ChangeInfo{{
 		Type:        AnalysisMR,
-		Identifier:  fmt.Sprintf("%s..%s", mainBranch, currentBranch),
+		Identifier:  fmt.Sprintf("%s...%s", target, currentBranch),
 		Diff:        diff,
-		Description: fmt.Sprintf("Merge Request: %s → %s", currentBranch, mainBranch),
+		Description: fmt.Sprintf("Merge Request: %s → %s", currentBranch, target),
+		Revision:    "HEAD",
 	}}
 }
 
-// getCurrentChanges получает текущие изменения
-func getCurrentChanges(gitClient *git.Client, verbose bool) []ChangeInfo {
-	fmt.Println("Анализ текущих изменений")
+// resolveMRTarget возвращает целевую ветку Merge Request: указанную флагом
+// --target или основную ветку репозитория
+func resolveMRTarget(gitClient *git.Client, target string) string {
+	if target == "" {
+		target = gitClient.GetMainBranch()
+		if target == "" {
+			fmt.Println("❌ Основная ветка не найдена (origin/HEAD, main, master). Укажите целевую ветку флагом --target")
+			os.Exit(1)
+		}
+	} else if !gitClient.RefExists(target) {
+		fmt.Printf("❌ Целевая ветка %s не найдена\n", target)
+		os.Exit(1)
+	}
+	return target
+}
+
+// printMRCommits выводит коммиты, входящие в Merge Request
+func printMRCommits(commits []git.Commit) {
+	if len(commits) == 0 {
+		fmt.Println("ℹ️  В ветке нет коммитов, отсутствующих в целевой ветке")
+		return
+	}
+
+	fmt.Printf("📜 Коммиты в MR (%d):\n", len(commits))
+	for _, commit := range commits {
+		fmt.Printf("  • %s %s (%s)\n", commit.ShortHash, commit.Subject, commit.Author)
+	}
+}
 
-	diff, err := gitClient.GetStatus()
+// getCurrentChanges получает объединенный diff изменений рабочей копии
+func getCurrentChanges(gitClient *git.Client, options git.WorkingTreeOptions, verbose bool) []ChangeInfo {
+	var categories []string
+	if options.Staged {
+		categories = append(categories, "подготовленные")
+	}
+	if options.Unstaged {
+		categories = append(categories, "неподготовленные")
+	}
+	if options.Untracked {
+		categories = append(categories, "неотслеживаемые файлы")
+	}
+	if len(categories) == 0 {
+		fmt.Println("❌ Все категории изменений исключены (--include-staged, --include-unstaged, --include-untracked)")
+		os.Exit(1)
+	}
+
+	fmt.Printf("Анализ текущих изменений: %s\n", strings.Join(categories, ", "))
+
+	diff, err := gitClient.GetWorkingTreeDiff(options)
 	if err != nil {
-		fmt.Printf("❌ Ошибка получения статуса: %v\n", err)
+		fmt.Printf("❌ Ошибка получения текущих изменений: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Только подготовленные изменения читаются из индекса, остальные - из рабочей копии
 	return []ChangeInfo{{
 		Type:        AnalysisCurrent,
 		Identifier:  "current",
 		Diff:        diff,
 		Description: "Текущие изменения",
+		WorkingTree: options.Unstaged || !options.Staged,
 	}}
 }
 
-// performAnalysis выполняет анализ изменений
-func performAnalysis(changes []ChangeInfo, verbose bool) []*types.CodeAnalysisResult {
+// diffContextNote поясняет модели формат изменений файла
+const diffContextNote = "Код дан в виде изменений: число слева - номер строки в новой версии файла, '+' - добавленная или измененная строка, '-' - удаленная строка (без номера), остальные строки - неизмененный контекст. Комментируй только добавленные и измененные строки, контекст используй для понимания. В поле line указывай номер строки новой версии файла."
+
+// performAnalysis выполняет анализ изменений. Diff каждого изменения разбивается
+// на файлы, и каждый файл анализируется отдельно со своим путем. Бинарные,
+// удаленные, игнорируемые (ignorePatterns, .gitignore, .miniReviewerignore),
+// слишком большие, сгенерированные и сторонние файлы пропускаются, а
+// переименования и смены режима без изменений содержимого только выводятся.
+// Файл проверяется и анализируется с настройками своего модуля, модуль
+// сохраняется в результате. Непустой selected оставляет только файлы выбранных модулей.
+func performAnalysis(gitClient *git.Client, attributor *analyzer.Attributor, changes []ChangeInfo, ignorePatterns []string, selected []types.WorkspaceModule, verbose bool) []*types.CodeAnalysisResult {
 	var results []*types.CodeAnalysisResult
+	ignore := filesystem.NewIgnoreMatcher(".", ignorePatterns)
 
 	for i, change := range changes {
 		if verbose {
@@ -374,14 +526,80 @@ func performAnalysis(changes []ChangeInfo, verbose bool) []*types.CodeAnalysisRe
 
 		if verbose {
 			fmt.Printf("   📄 Размер изменений: %d символов\n", len(change.Diff))
-			fmt.Printf("   🧠 Запускаю AI-анализ...\n")
 		}
 
-		// Выполняем анализ в зависимости от настроек
-		result := analyzeChange(change, verbose)
-		if result != nil {
-			result.File = change.Identifier
-			results = append(results, result)
+		// Отпечатки строятся по той же версии файлов, к которой относятся строки проблем
+		readContent := func(path string) ([]byte, error) {
+			return loadChangedFileContent(gitClient, change, path)
+		}
+
+		files := git.ParseDiff(change.Diff)
+		if len(files) == 0 {
+			// Вывод не в формате diff анализируется целиком
+			if verbose {
+				fmt.Printf("   🧠 Запускаю AI-анализ...\n")
+			}
+			result := analyzeChange(viper.GetViper(), change.Diff, change.Description, verbose)
+			if result != nil {
+				result.File = change.Identifier
+				analyzer.AssignFingerprintsFrom([]*types.CodeAnalysisResult{result}, readContent)
+				results = append(results, result)
+			}
+			continue
+		}
+
+		for _, file := range files {
+			if reason := skipFileDiffReason(file, ignore); reason != "" {
+				if verbose {
+					fmt.Printf("   ⏭️  Пропускаю %s: %s\n", file.Path(), reason)
+				}
+				continue
+			}
+
+			if !moduleSelected(selected, file.Path()) {
+				if verbose {
+					fmt.Printf("   ⏭️  Пропускаю %s: вне выбранных модулей\n", file.Path())
+				}
+				continue
+			}
+
+			module := filesystem.ModuleOf(detectWorkspaceModules(verbose), file.Path())
+			config := moduleConfig(module)
+			if reason := skipChangedContentReason(gitClient, change, file, maxFileSize(config)); reason != "" {
+				if verbose {
+					fmt.Printf("   ⏭️  Пропускаю %s: %s\n", file.Path(), reason)
+				}
+				continue
+			}
+
+			if len(file.Hunks) == 0 {
+				// Переименование, копирование или смена режима: анализировать нечего
+				fmt.Printf("   🔀 %s: %s\n", file.Path(), file.Summary())
+				continue
+			}
+
+			if verbose {
+				fmt.Printf("   🧠 Анализирую %s (фрагментов: %d)...\n", file.Path(), len(file.Hunks))
+			}
+
+			if result := analyzeFileDiff(config, gitClient, change, file, verbose); result != nil {
+				// Автор строки берется из той же ревизии, что и новая версия файла
+				if attributor != nil {
+					attributor.AttributeIssues(result.Issues, result.File, blameRevision(change))
+				}
+				analyzer.AssignFingerprintsFrom([]*types.CodeAnalysisResult{result}, readContent)
+				// Хеш позволяет fix --apply проверить, что файл не менялся после анализа;
+				// он имеет смысл, только если анализировалась рабочая копия
+				if change.WorkingTree {
+					if content, err := readContent(file.Path()); err == nil {
+						result.FileHash = analyzer.ContentHash(content)
+					}
+				}
+				if module != nil {
+					result.Module = module.Path
+				}
+				results = append(results, result)
+			}
 		}
 
 		if verbose {
@@ -392,27 +610,133 @@ func performAnalysis(changes []ChangeInfo, verbose bool) []*types.CodeAnalysisRe
 	return results
 }
 
+// blameRevision возвращает ревизию для git blame новой версии файлов изменения.
+// Строки подготовленных изменений нумеруются по индексу, а не по рабочей копии.
+func blameRevision(change ChangeInfo) string {
+	if change.Revision == "" && !change.WorkingTree {
+		return git.IndexRevision
+	}
+	return change.Revision
+}
+
+// buildFileChangeCode возвращает изменения файла для промпта. Если доступна новая
+// версия файла, hunk расширяются до охватывающих функций (diff.expand_hunks).
+func buildFileChangeCode(config *viper.Viper, gitClient *git.Client, change ChangeInfo, file git.FileDiff, verbose bool) string {
+	if !config.GetBool("diff.expand_hunks") {
+		return file.Annotated()
+	}
+
+	content, err := loadChangedFileContent(gitClient, change, file.Path())
+	if err != nil {
+		if verbose {
+			fmt.Printf("   ⚠️  Не удалось получить новую версию %s, анализирую только diff: %v\n", file.Path(), err)
+		}
+		return file.Annotated()
+	}
+
+	return analyzer.ExpandFileDiff(file, content, config.GetInt("diff.context_lines"), config.GetInt("diff.max_function_lines"))
+}
+
+// loadChangedFileContent получает содержимое файла после изменения
+func loadChangedFileContent(gitClient *git.Client, change ChangeInfo, path string) ([]byte, error) {
+	if change.WorkingTree {
+		return os.ReadFile(path)
+	}
+
+	content, err := gitClient.GetFileContent(change.Revision, path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// skipFileDiffReason возвращает причину, по которой изменения файла не анализируются.
+// Переименования и смены режима без изменений содержимого не пропускаются: они
+// выводятся кратким описанием.
+func skipFileDiffReason(file git.FileDiff, ignore *filesystem.IgnoreMatcher) string {
+	if pattern := ignore.Match(file.Path(), false); pattern != "" {
+		return "игнорируется паттерном " + pattern
+	}
+
+	switch {
+	case file.Binary:
+		return "бинарный файл"
+	case file.IsDeleted():
+		return "файл удален"
+	case len(file.Hunks) == 0 && file.Kind() != git.ChangeRenamed && file.Kind() != git.ChangeCopied && !file.ModeChanged():
+		return "нет изменений содержимого"
+	}
+	return ""
+}
+
+// skipChangedContentReason возвращает причину, по которой не анализируется новая
+// версия файла: превышение analysis.max_file_size, сгенерированный код, lockfile,
+// минифицированный бандл, сторонний код или бинарное содержимое
+func skipChangedContentReason(gitClient *git.Client, change ChangeInfo, file git.FileDiff, maxSize int64) string {
+	if len(file.Hunks) == 0 {
+		return ""
+	}
+
+	// Без новой версии файла проверяется только путь
+	content, _ := loadChangedFileContent(gitClient, change, file.Path())
+	if maxSize > 0 && int64(len(content)) > maxSize {
+		return fmt.Sprintf("%s (%s > %s)", filesystem.SkipReasonName(filesystem.SkipTooLarge),
+			filesystem.FormatSize(int64(len(content))), filesystem.FormatSize(maxSize))
+	}
+
+	reason, detail := filesystem.DetectSkip(file.Path(), content)
+	switch {
+	case reason == "":
+		return ""
+	case detail != "":
+		return fmt.Sprintf("%s (%s)", filesystem.SkipReasonName(reason), detail)
+	}
+	return filesystem.SkipReasonName(reason)
+}
+
+// analyzeFileDiff анализирует изменения одного файла и привязывает проблемы
+// к пути файла и строкам его новой версии. Настройки берутся из config модуля файла.
+func analyzeFileDiff(config *viper.Viper, gitClient *git.Client, change ChangeInfo, file git.FileDiff, verbose bool) *types.CodeAnalysisResult {
+	description := fmt.Sprintf("%s (%s). %s", file.Path(), change.Description, diffContextNote)
+	if summary := file.Summary(); summary != "" {
+		description = fmt.Sprintf("%s [%s] (%s). %s", file.Path(), summary, change.Description, diffContextNote)
+	}
+
+	result := analyzeChange(config, buildFileChangeCode(config, gitClient, change, file, verbose), description, verbose)
+	if result == nil {
+		return nil
+	}
+
+	result.File = file.Path()
+	for i := range result.Issues {
+		result.Issues[i].File = file.Path()
+		result.Issues[i].Line = file.MapLine(result.Issues[i].Line)
+	}
+
+	return result
+}
+
 // analyzeChange анализирует одно изменение
-func analyzeChange(change ChangeInfo, verbose bool) *types.CodeAnalysisResult {
+func analyzeChange(config *viper.Viper, diff, description string, verbose bool) *types.CodeAnalysisResult {
 	var results []*types.CodeAnalysisResult
 
 	// Проверяем, какие типы анализа включены
-	if viper.GetBool("analysis.enable_quality") {
-		qualityResult := analyzeWithQuality(change.Diff, change.Description, verbose)
+	if config.GetBool("analysis.enable_quality") {
+		qualityResult := analyzeWithQuality(config, diff, description, verbose)
 		if qualityResult != nil {
 			results = append(results, qualityResult)
 		}
 	}
 
-	if viper.GetBool("analysis.enable_architecture") {
-		archResult := analyzeWithArchitecture(change.Diff, change.Description, verbose)
+	if config.GetBool("analysis.enable_architecture") {
+		archResult := analyzeWithArchitecture(config, diff, description, verbose)
 		if archResult != nil {
 			results = append(results, archResult)
 		}
 	}
 
-	if viper.GetBool("analysis.enable_security") {
-		securityResult := analyzeWithSecurity(change.Diff, change.Description, verbose)
+	if config.GetBool("analysis.enable_security") {
+		securityResult := analyzeWithSecurity(config, diff, description, verbose)
 		if securityResult != nil {
 			results = append(results, securityResult)
 		}
@@ -424,12 +748,12 @@ func analyzeChange(change ChangeInfo, verbose bool) *types.CodeAnalysisResult {
 	}
 
 	// Объединяем результаты в один
-	return mergeAnalysisResults(results, change.Description)
+	return mergeAnalysisResults(results, description)
 }
 
 // analyzeWithQuality анализирует с помощью анализатора качества
-func analyzeWithQuality(diff, description string, verbose bool) *types.CodeAnalysisResult {
-	qualityAnalyzer := analyzer.NewQualityAnalyzer()
+func analyzeWithQuality(config *viper.Viper, diff, description string, verbose bool) *types.CodeAnalysisResult {
+	qualityAnalyzer := analyzer.NewQualityAnalyzerWithConfig(config)
 	result, err := qualityAnalyzer.Analyze(diff, fmt.Sprintf("Quality analysis of %s", description))
 	if err != nil {
 		if verbose {
@@ -441,8 +765,8 @@ func analyzeWithQuality(diff, description string, verbose bool) *types.CodeAnaly
 }
 
 // analyzeWithArchitecture анализирует с помощью анализатора архитектуры
-func analyzeWithArchitecture(diff, description string, verbose bool) *types.CodeAnalysisResult {
-	archAnalyzer := analyzer.NewArchitectureAnalyzer()
+func analyzeWithArchitecture(config *viper.Viper, diff, description string, verbose bool) *types.CodeAnalysisResult {
+	archAnalyzer := analyzer.NewArchitectureAnalyzerWithConfig(config)
 	result, err := archAnalyzer.Analyze(diff, fmt.Sprintf("Architecture analysis of %s", description))
 	if err != nil {
 		if verbose {
@@ -454,8 +778,8 @@ func analyzeWithArchitecture(diff, description string, verbose bool) *types.Code
 }
 
 // analyzeWithSecurity анализирует с помощью анализатора безопасности
-func analyzeWithSecurity(diff, description string, verbose bool) *types.CodeAnalysisResult {
-	securityAnalyzer := analyzer.NewSecurityAnalyzer()
+func analyzeWithSecurity(config *viper.Viper, diff, description string, verbose bool) *types.CodeAnalysisResult {
+	securityAnalyzer := analyzer.NewSecurityAnalyzerWithConfig(config)
 	result, err := securityAnalyzer.Analyze(diff, fmt.Sprintf("Security analysis of %s", description))
 	if err != nil {
 		if verbose {
@@ -484,12 +808,19 @@ func mergeAnalysisResults(results []*types.CodeAnalysisResult, description strin
 	// Вычисляем среднюю оценку
 	avgScore := totalScore / len(results)
 
-	return &types.CodeAnalysisResult{
+	merged := &types.CodeAnalysisResult{
 		Issues:    allIssues,
 		Score:     avgScore,
 		File:      description,
 		Timestamp: results[0].Timestamp, // Используем время первого результата
 	}
+
+	// Одна и та же проблема часто находится несколькими анализаторами
+	if viper.GetBool("dedup.enabled") {
+		analyzer.NewDeduplicator().DeduplicateResult(merged)
+	}
+
+	return merged
 }
 
 // printAnalysisResults выводит результаты анализа
@@ -505,6 +836,9 @@ func printAnalysisResults(results []*types.CodeAnalysisResult, analysisType Anal
 		fmt.Printf("\n📁 %s:\n", result.File)
 		fmt.Printf("   Оценка: %d/100\n", result.Score)
 		fmt.Printf("   Найдено проблем: %d\n", len(result.Issues))
+		if result.DuplicatesCollapsed > 0 {
+			fmt.Printf("   Объединено дубликатов: %d\n", result.DuplicatesCollapsed)
+		}
 
 		if verbose {
 			fmt.Printf("   Временная метка: %s\n", result.Timestamp.Format("2006-01-02 15:04:05"))
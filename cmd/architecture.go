@@ -5,9 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/depgraph"
 	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/layering"
 	"miniReviewer/internal/types"
 
 	"github.com/spf13/cobra"
@@ -17,26 +20,31 @@ import (
 // ArchitectureCmd команда для анализа архитектуры
 func ArchitectureCmd() *cobra.Command {
 	var path, output string
+	var rulesOnly, hierarchical bool
 
 	cmd := &cobra.Command{
 		Use:   "architecture",
 		Short: "AI-анализ архитектуры проекта",
 		Long: `Анализирует архитектуру проекта или файла с использованием AI (Ollama).
 Оценивает структуру, предлагает улучшения и выявляет проблемы.
-Может анализировать как отдельные файлы, так и целые директории.`,
+Может анализировать как отдельные файлы, так и целые директории.
+Проверяет правила зависимостей между слоями из architecture.rules и
+завершается с ненулевым кодом при их нарушении.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runArchitectureAnalysis(path, output)
+			runArchitectureAnalysis(path, output, rulesOnly, hierarchical)
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "path", ".", "путь для анализа")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
+	cmd.Flags().BoolVar(&rulesOnly, "rules-only", false, "только проверить правила слоев без AI-анализа (для CI)")
+	cmd.Flags().BoolVar(&hierarchical, "hierarchical", false, "иерархический анализ директории: описания файлов -> описания пакетов -> обзор архитектуры")
 
 	return cmd
 }
 
 // runArchitectureAnalysis выполняет анализ архитектуры
-func runArchitectureAnalysis(path, output string) {
+func runArchitectureAnalysis(path, output string, rulesOnly, hierarchical bool) {
 	verbose := viper.GetBool("verbose")
 
 	printArchitectureHeader(path, verbose)
@@ -48,25 +56,69 @@ func runArchitectureAnalysis(path, output string) {
 		os.Exit(1)
 	}
 
-	// Выполняем анализ
-	var result *types.CodeAnalysisResult
-	if !fileInfo.IsDir() {
-		result = analyzeArchitectureFile(path, verbose)
-	} else {
-		result = analyzeArchitectureProject(path, verbose)
+	// Директория монорепозитория анализируется по модулям, каждый со своими
+	// настройками и правилами слоев относительно корня модуля
+	targets := []*types.WorkspaceModule{filesystem.ModuleOf(detectWorkspaceModules(verbose), path)}
+	dirs := []string{path}
+	if fileInfo.IsDir() {
+		targets, dirs = moduleTargets(path, verbose)
 	}
 
-	// Выводим результаты
-	printArchitectureResults(result, path, fileInfo.IsDir(), verbose)
+	var results []*types.CodeAnalysisResult
+	violations := 0
+	for i, module := range targets {
+		if len(targets) > 1 {
+			fmt.Printf("\n📦 Модуль %s\n", moduleTitle(module))
+		}
+		result, moduleViolations := analyzeArchitectureTarget(moduleConfig(module), dirs[i], fileInfo.IsDir(), rulesOnly, hierarchical, verbose)
+		results = append(results, result)
+		violations += moduleViolations
+	}
 
 	// Сохраняем результаты если указан файл
 	if output != "" {
-		saveArchitectureResults(result, output, verbose)
+		saveArchitectureResults(results, output, verbose)
+	}
+
+	if violations > 0 {
+		fmt.Printf("\n❌ Нарушено правил архитектуры: %d\n", violations)
+		os.Exit(1)
 	}
 
 	fmt.Println("✅ Анализ архитектуры завершен")
 }
 
+// analyzeArchitectureTarget анализирует архитектуру файла или директории, проверяет
+// правила слоев с настройками config и выводит результаты. Возвращает результат и
+// количество нарушений правил.
+func analyzeArchitectureTarget(config *viper.Viper, path string, isDir, rulesOnly, hierarchical, verbose bool) (*types.CodeAnalysisResult, int) {
+	var result *types.CodeAnalysisResult
+	switch {
+	case rulesOnly:
+		result = &types.CodeAnalysisResult{
+			File:      path,
+			Issues:    []types.Issue{},
+			Score:     100,
+			Timestamp: time.Now(),
+		}
+	case !isDir:
+		result = analyzeArchitectureFile(config, path, verbose)
+	case hierarchical:
+		result = analyzeArchitectureHierarchical(config, path, verbose)
+	default:
+		result = analyzeArchitectureProject(config, path, verbose)
+	}
+
+	// Проверяем правила зависимостей между слоями
+	violations, root := checkLayeringRules(config, path, isDir, verbose)
+	addLayeringViolations(result, violations, root)
+
+	analyzer.AssignFingerprints([]*types.CodeAnalysisResult{result})
+
+	printArchitectureResults(result, path, isDir, verbose)
+	return result, len(violations)
+}
+
 // printArchitectureHeader выводит заголовок анализа архитектуры
 func printArchitectureHeader(path string, verbose bool) {
 	fmt.Println("🏗️  Запуск анализа архитектуры...")
@@ -86,7 +138,7 @@ func printArchitectureSettings() {
 }
 
 // analyzeArchitectureFile анализирует архитектуру отдельного файла
-func analyzeArchitectureFile(filePath string, verbose bool) *types.CodeAnalysisResult {
+func analyzeArchitectureFile(config *viper.Viper, filePath string, verbose bool) *types.CodeAnalysisResult {
 	if verbose {
 		fmt.Printf("📄 Анализирую файл: %s\n", filePath)
 	}
@@ -106,8 +158,10 @@ func analyzeArchitectureFile(filePath string, verbose bool) *types.CodeAnalysisR
 	// Определяем тип файла для контекста
 	context := getFileContext(filePath)
 
-	architectureAnalyzer := analyzer.NewArchitectureAnalyzer()
-	result, err := architectureAnalyzer.Analyze(string(content), context)
+	related := buildRelatedContext(config, filePath, content, verbose)
+
+	architectureAnalyzer := analyzer.NewArchitectureAnalyzerWithConfig(config)
+	result, err := architectureAnalyzer.AnalyzeWithRelated(string(content), context, related)
 	if err != nil {
 		fmt.Printf("❌ Ошибка AI-анализа: %v\n", err)
 		os.Exit(1)
@@ -117,17 +171,18 @@ func analyzeArchitectureFile(filePath string, verbose bool) *types.CodeAnalysisR
 		fmt.Println("✅ AI-анализ файла завершен успешно")
 	}
 
+	result.File = filePath
+	result.FileHash = analyzer.ContentHash(content)
 	return result
 }
 
 // analyzeArchitectureProject анализирует архитектуру проекта
-func analyzeArchitectureProject(projectPath string, verbose bool) *types.CodeAnalysisResult {
+func analyzeArchitectureProject(config *viper.Viper, projectPath string, verbose bool) *types.CodeAnalysisResult {
 	if verbose {
 		fmt.Println("📁 Сканирую структуру проекта...")
 	}
 
-	ignorePatterns := viper.GetStringSlice("analysis.ignore_patterns")
-	scanner := filesystem.NewScanner(ignorePatterns, 0)
+	scanner := newScanner(config, config.GetStringSlice("analysis.ignore_patterns"))
 
 	structure, err := scanner.AnalyzeProjectStructure(projectPath)
 	if err != nil {
@@ -146,8 +201,15 @@ func analyzeArchitectureProject(projectPath string, verbose bool) *types.CodeAna
 		fmt.Println("🧠 Запускаю AI-анализ архитектуры проекта...")
 	}
 
-	architectureAnalyzer := analyzer.NewArchitectureAnalyzer()
-	result, err := architectureAnalyzer.Analyze(structure, "Project architecture analysis")
+	// Для Go модулей строим граф импортов и передаем его модели как факты
+	graph := buildImportGraph(projectPath, verbose)
+	dependencies := ""
+	if graph != nil {
+		dependencies = graph.Render()
+	}
+
+	architectureAnalyzer := analyzer.NewArchitectureAnalyzerWithConfig(config)
+	result, err := architectureAnalyzer.AnalyzeProject(structure, dependencies)
 	if err != nil {
 		fmt.Printf("❌ Ошибка AI-анализа: %v\n", err)
 		os.Exit(1)
@@ -157,9 +219,201 @@ func analyzeArchitectureProject(projectPath string, verbose bool) *types.CodeAna
 		fmt.Println("✅ AI-анализ проекта завершен успешно")
 	}
 
+	if graph != nil {
+		addImportGraphFindings(result, graph)
+	}
+
 	return result
 }
 
+// analyzeArchitectureHierarchical анализирует архитектуру проекта в несколько этапов:
+// описания файлов сводятся в описания пакетов, а финальный обзор строится по описаниям
+// пакетов и графу импортов. Промежуточные описания кэшируются между запусками.
+func analyzeArchitectureHierarchical(config *viper.Viper, projectPath string, verbose bool) *types.CodeAnalysisResult {
+	graph := buildImportGraph(projectPath, verbose)
+
+	overview, err := buildModuleOverview(config, projectPath, graph, verbose)
+	if err != nil {
+		fmt.Printf("❌ Ошибка построения обзора проекта: %v\n", err)
+		os.Exit(1)
+	}
+
+	printModuleOverview(overview)
+
+	if verbose {
+		fmt.Println("🧠 Запускаю AI-анализ архитектуры по описаниям пакетов...")
+	}
+
+	architectureAnalyzer := analyzer.NewArchitectureAnalyzerWithConfig(config)
+	result, err := architectureAnalyzer.AnalyzeOverview(analyzer.RenderOverview(overview), overview.Dependencies)
+	if err != nil {
+		fmt.Printf("❌ Ошибка AI-анализа: %v\n", err)
+		os.Exit(1)
+	}
+
+	if verbose {
+		fmt.Println("✅ AI-анализ проекта завершен успешно")
+	}
+
+	if graph != nil {
+		addImportGraphFindings(result, graph)
+	}
+
+	return result
+}
+
+// buildImportGraph строит граф импортов Go модуля; для проектов без go.mod возвращает nil
+func buildImportGraph(projectPath string, verbose bool) *depgraph.Graph {
+	if root, _ := findGoModule(projectPath); root == "" {
+		return nil
+	}
+
+	graph, err := depgraph.Build(projectPath)
+	if err != nil {
+		fmt.Printf("⚠️  Не удалось построить граф импортов: %v\n", err)
+		return nil
+	}
+
+	printImportGraph(graph, verbose)
+	return graph
+}
+
+// printImportGraph выводит метрики графа импортов
+func printImportGraph(graph *depgraph.Graph, verbose bool) {
+	fmt.Printf("\n🔗 Граф импортов модуля %s (%d пакетов):\n", graph.Module, len(graph.Packages))
+	fmt.Printf("  %-40s %7s %8s %6s\n", "Пакет", "Fan-in", "Fan-out", "I")
+	for _, m := range graph.Metrics() {
+		fmt.Printf("  %-40s %7d %8d %6.2f\n", graph.RelativePath(m.Package), m.FanIn, m.FanOut, m.Instability)
+	}
+
+	cycles := graph.Cycles()
+	if len(cycles) > 0 {
+		fmt.Printf("  🔄 Циклов импортов: %d\n", len(cycles))
+	}
+
+	if verbose {
+		fmt.Printf("\n%s\n", graph.Render())
+	}
+}
+
+// addImportGraphFindings добавляет в результат детерминированные проблемы графа импортов.
+// Каждая такая проблема снижает оценку на 10 баллов.
+func addImportGraphFindings(result *types.CodeAnalysisResult, graph *depgraph.Graph) {
+	findings := graph.Findings()
+	for i := range findings {
+		findings[i].File = moduleFilePath(graph.Root, findings[i].File)
+	}
+
+	result.Issues = append(findings, result.Issues...)
+	result.Score -= len(findings) * 10
+	if result.Score < 0 {
+		result.Score = 0
+	}
+}
+
+// loadLayeringRules читает правила слоев из architecture.rules
+func loadLayeringRules(config *viper.Viper) ([]layering.Rule, error) {
+	var rules []layering.Rule
+	if err := config.UnmarshalKey("architecture.rules", &rules); err != nil {
+		return nil, fmt.Errorf("ошибка чтения architecture.rules: %v", err)
+	}
+	return rules, nil
+}
+
+// checkLayeringRules проверяет импорты файлов по правилам слоев и возвращает нарушения
+// вместе с корнем проекта. Корнем считается корень Go модуля, для модулей
+// монорепозитория на других языках - директория модуля, иначе - анализируемый каталог.
+func checkLayeringRules(config *viper.Viper, path string, isDir bool, verbose bool) ([]layering.Violation, string) {
+	rules, err := loadLayeringRules(config)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if len(rules) == 0 {
+		return nil, ""
+	}
+
+	root, module := findGoModule(path)
+	if root == "" {
+		root = path
+		if owner := filesystem.ModuleOf(workspaceModules, path); owner != nil {
+			root = owner.Path
+		} else if !isDir {
+			root = filepath.Dir(path)
+		}
+		root, _ = filepath.Abs(root)
+	}
+
+	checker := layering.NewChecker(root, module, rules)
+	if err := checker.Validate(); err != nil {
+		fmt.Printf("❌ Некорректные правила архитектуры: %v\n", err)
+		os.Exit(1)
+	}
+
+	files := []string{path}
+	if isDir {
+		scanner := newScanner(config, config.GetStringSlice("analysis.ignore_patterns"))
+		files, err = scanner.FindSupportedFiles(path)
+		if err != nil {
+			fmt.Printf("❌ Ошибка поиска файлов: %v\n", err)
+			os.Exit(1)
+		}
+		printSkippedFiles(scanner.Skipped(), verbose)
+	}
+
+	if verbose {
+		fmt.Printf("📏 Проверяю правила слоев (%d правил) в %d файлах...\n", len(rules), len(files))
+	}
+
+	var violations []layering.Violation
+	for _, file := range files {
+		absolute, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			if verbose {
+				fmt.Printf("   ⚠️  Ошибка чтения %s: %v\n", file, err)
+			}
+			continue
+		}
+
+		violations = append(violations, checker.CheckFile(absolute, content)...)
+	}
+
+	return violations, root
+}
+
+// addLayeringViolations добавляет нарушения правил слоев в результат как проблемы важности high
+func addLayeringViolations(result *types.CodeAnalysisResult, violations []layering.Violation, root string) {
+	for _, violation := range violations {
+		issue := violation.ToIssue()
+		issue.File = moduleFilePath(root, issue.File)
+		result.Issues = append(result.Issues, issue)
+	}
+
+	result.Score -= len(violations) * 10
+	if result.Score < 0 {
+		result.Score = 0
+	}
+}
+
+// moduleFilePath переводит путь относительно корня модуля в путь относительно текущего каталога
+func moduleFilePath(root, file string) string {
+	if file == "" {
+		return ""
+	}
+
+	absolute := filepath.Join(root, filepath.FromSlash(file))
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, absolute); err == nil {
+			return rel
+		}
+	}
+	return absolute
+}
+
 // getFileContext возвращает контекст для анализа файла
 func getFileContext(filePath string) string {
 	ext := strings.ToLower(filepath.Ext(filePath))
@@ -208,6 +462,8 @@ func printArchitectureIssues(result *types.CodeAnalysisResult, path string, isPr
 		fileName := path
 		if !isProject {
 			fileName = filepath.Base(path)
+		} else if issue.File != "" {
+			fileName = issue.File
 		}
 		issuesByFile[fileName] = append(issuesByFile[fileName], issue)
 	}
@@ -348,12 +604,12 @@ func printTypeStatistics(typeCounts map[string]int) {
 }
 
 // saveArchitectureResults сохраняет результаты анализа архитектуры в файл
-func saveArchitectureResults(result *types.CodeAnalysisResult, output string, verbose bool) {
+func saveArchitectureResults(results []*types.CodeAnalysisResult, output string, verbose bool) {
 	if verbose {
 		fmt.Printf("💾 Сохраняю результаты в файл: %s\n", output)
 	}
 
-	if err := saveResultsToFile([]*types.CodeAnalysisResult{result}, output); err != nil {
+	if err := saveResultsToFile(results, output); err != nil {
 		fmt.Printf("❌ Ошибка сохранения: %v\n", err)
 	} else {
 		fmt.Printf("\n💾 Результаты сохранены в: %s\n", output)
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/git"
+
+	"github.com/spf13/viper"
+)
+
+// newAttributor создает определитель авторов строк, если git.enable_blame включен
+// и текущий каталог находится в git репозитории
+func newAttributor(verbose bool) *analyzer.Attributor {
+	if !viper.GetBool("git.enable_blame") {
+		return nil
+	}
+
+	gitClient := git.NewClient("")
+	if !gitClient.IsRepository() {
+		if verbose {
+			fmt.Println("ℹ️  Git репозиторий не найден, авторы строк не определяются")
+		}
+		return nil
+	}
+
+	return analyzer.NewAttributor(gitClient)
+}
+
+// validateAuthorFilter проверяет, что фильтр по автору можно применить
+func validateAuthorFilter(author string, attributor *analyzer.Attributor) error {
+	if author != "" && attributor == nil {
+		return fmt.Errorf("фильтр --author требует git репозитория и git.enable_blame: true")
+	}
+	return nil
+}
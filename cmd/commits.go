@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// CommitsCmd команда для проверки качества сообщений и истории коммитов
+func CommitsCmd() *cobra.Command {
+	var from, to, revRange, output string
+	var limit int
+	var aiCheck bool
+
+	cmd := &cobra.Command{
+		Use:   "commits",
+		Short: "Проверка сообщений и истории коммитов",
+		Long: `Проверяет коммиты диапазона: формат Conventional Commits, соответствие
+сообщения изменениям (AI), слишком большие коммиты и коммиты, смешивающие
+несвязанные изменения, а также WIP и fixup коммиты, оставленные перед слиянием.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("ai") {
+				aiCheck = viper.GetBool("commits.ai_message_check")
+			}
+			if !cmd.Flags().Changed("limit") {
+				limit = viper.GetInt("git.max_commit_history")
+			}
+			runCommitsAnalysis(resolveCommitRange(revRange, from, to), limit, aiCheck, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "начальный коммит или ветка (не включается)")
+	cmd.Flags().StringVar(&to, "to", "", "конечный коммит или ветка (по умолчанию HEAD)")
+	cmd.Flags().StringVar(&revRange, "range", "", "диапазон коммитов в формате git (например main..HEAD)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "максимальное количество проверяемых коммитов (по умолчанию git.max_commit_history)")
+	cmd.Flags().BoolVar(&aiCheck, "ai", false, "проверять соответствие сообщения изменениям с помощью AI")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
+
+	return cmd
+}
+
+// resolveCommitRange определяет диапазон коммитов по флагам
+func resolveCommitRange(revRange, from, to string) string {
+	if revRange != "" {
+		return revRange
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	if from != "" {
+		return from + ".." + to
+	}
+	return to
+}
+
+// runCommitsAnalysis выполняет проверку коммитов
+func runCommitsAnalysis(revRange string, limit int, aiCheck bool, output string) {
+	verbose := viper.GetBool("verbose")
+
+	if !viper.GetBool("git.enable_commit_analysis") {
+		fmt.Println("ℹ️  Анализ коммитов отключен (git.enable_commit_analysis: false)")
+		return
+	}
+
+	gitClient := validateGitRepository(verbose)
+	skipMerges := viper.GetBool("git.ignore_merge_commits")
+
+	fmt.Println("📝 Запуск проверки коммитов...")
+	fmt.Printf("Диапазон: %s\n", revRange)
+	if verbose {
+		fmt.Printf("Лимит коммитов: %d\n", limit)
+		fmt.Printf("Пропуск merge-коммитов: %t\n", skipMerges)
+		fmt.Printf("AI-проверка сообщений: %t\n", aiCheck)
+	}
+
+	commits, err := gitClient.GetCommits(revRange, limit, skipMerges)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(commits) == 0 {
+		fmt.Println("ℹ️  Коммиты для проверки не найдены")
+		return
+	}
+
+	fmt.Printf("📋 Найдено коммитов: %d\n", len(commits))
+
+	reviews := reviewCommits(gitClient, commits, aiCheck, verbose)
+	printCommitReviews(reviews)
+	printCommitStatistics(reviews)
+
+	if output != "" {
+		if err := analyzer.SaveResultsToFile(reviews, output); err != nil {
+			fmt.Printf("❌ Ошибка сохранения: %v\n", err)
+		} else {
+			fmt.Printf("\n💾 Результаты проверки коммитов сохранены в: %s\n", output)
+		}
+	}
+
+	fmt.Println("✅ Проверка коммитов завершена")
+}
+
+// reviewCommits проверяет каждый коммит. Для merge-коммитов проверяется только
+// сообщение: их diff повторяет изменения влитой ветки.
+func reviewCommits(gitClient *git.Client, commits []git.Commit, aiCheck, verbose bool) []types.CommitReview {
+	commitAnalyzer := analyzer.NewCommitAnalyzer()
+
+	// WIP/fixup коммиты, которых еще нет в основной ветке, попадут в нее при слиянии
+	mainBranch := ""
+	if viper.GetBool("git.enable_branch_analysis") {
+		mainBranch = gitClient.GetMainBranch()
+	}
+
+	var reviews []types.CommitReview
+	for i, commit := range commits {
+		if verbose {
+			fmt.Printf("🔍 [%d/%d] %s %s\n", i+1, len(commits), commit.ShortHash, commit.Subject)
+		}
+
+		issues := commitAnalyzer.CheckMessage(commit)
+
+		beforeMerge := mainBranch != "" && !gitClient.IsAncestor(commit.Hash, mainBranch)
+		issues = append(issues, commitAnalyzer.CheckLeftover(commit, beforeMerge)...)
+
+		if !commit.IsMerge() {
+			issues = append(issues, commitAnalyzer.CheckSize(commit)...)
+
+			if aiCheck {
+				issues = append(issues, checkCommitMessageWithAI(gitClient, commitAnalyzer, commit, verbose)...)
+			}
+		}
+
+		reviews = append(reviews, types.CommitReview{
+			Hash:    commit.Hash,
+			Author:  commit.Author,
+			Date:    commit.Date,
+			Subject: commit.Subject,
+			Files:   len(commit.Files),
+			Changed: commit.ChangedLines(),
+			Issues:  issues,
+		})
+	}
+
+	return reviews
+}
+
+// checkCommitMessageWithAI сравнивает сообщение коммита с его diff
+func checkCommitMessageWithAI(gitClient *git.Client, commitAnalyzer *analyzer.CommitAnalyzer, commit git.Commit, verbose bool) []types.Issue {
+	diff, err := gitClient.GetCommitDiff(commit.Hash)
+	if err != nil {
+		if verbose {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+		return nil
+	}
+
+	issues, err := commitAnalyzer.CheckMessageMatchesDiff(commit, diff)
+	if err != nil && verbose {
+		fmt.Printf("⚠️  %s: %v\n", commit.ShortHash, err)
+	}
+	return issues
+}
+
+// printCommitReviews выводит проблемы по коммитам
+func printCommitReviews(reviews []types.CommitReview) {
+	fmt.Println("\n📝 Результаты проверки коммитов:")
+
+	clean := 0
+	for _, review := range reviews {
+		if len(review.Issues) == 0 {
+			clean++
+			continue
+		}
+
+		fmt.Printf("\n🔖 %s %s\n", shortCommitHash(review.Hash), review.Subject)
+		fmt.Printf("   👤 %s, %s | файлов: %d, строк: %d\n", review.Author, review.Date.Format("2006-01-02"), review.Files, review.Changed)
+		for _, issue := range review.Issues {
+			fmt.Printf("   %s [%s] %s\n", getCommitSeverityIcon(issue.Severity), strings.ToUpper(issue.Severity), issue.Message)
+			if issue.Suggestion != "" {
+				fmt.Printf("      💡 %s\n", issue.Suggestion)
+			}
+		}
+	}
+
+	fmt.Printf("\n✅ Коммитов без замечаний: %d из %d\n", clean, len(reviews))
+}
+
+// printCommitStatistics выводит статистику проблем коммитов
+func printCommitStatistics(reviews []types.CommitReview) {
+	severityCounts := make(map[string]int)
+	total := 0
+	for _, review := range reviews {
+		for _, issue := range review.Issues {
+			severityCounts[issue.Severity]++
+			total++
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+
+	fmt.Printf("\n📊 Статистика (всего замечаний: %d):\n", total)
+	printSeverityStatistics(severityCounts)
+}
+
+// getCommitSeverityIcon возвращает иконку для уровня важности
+func getCommitSeverityIcon(severity string) string {
+	switch severity {
+	case "critical":
+		return "🚨"
+	case "high":
+		return "⚠️"
+	case "medium":
+		return "⚡"
+	case "low":
+		return "💡"
+	default:
+		return "ℹ️"
+	}
+}
+
+// shortCommitHash возвращает сокращенный хеш коммита
+func shortCommitHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"miniReviewer/internal/codecontext"
+
+	"github.com/spf13/viper"
+)
+
+// buildRelatedContext собирает межфайловый контекст (связанные объявления) для
+// файла с настройками context.* из config
+func buildRelatedContext(config *viper.Viper, file string, content []byte, verbose bool) string {
+	if !config.GetBool("context.enabled") {
+		return ""
+	}
+
+	related, err := codecontext.Build(file, content, config.GetInt("context.max_tokens"))
+	if err != nil {
+		if verbose {
+			fmt.Printf("   ⚠️  Не удалось собрать межфайловый контекст: %v\n", err)
+		}
+		return ""
+	}
+
+	if verbose && related != "" {
+		fmt.Printf("   🔗 Межфайловый контекст: ~%d токенов\n", codecontext.EstimateTokens(related))
+	}
+
+	return related
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/reporter"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// DescribeCmd команда для генерации сообщения коммита и описания PR по изменениям
+func DescribeCmd() *cobra.Command {
+	var revRange, from, to, target, commitMsgFile, format, output string
+	var staged, mr bool
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Генерация сообщения коммита и описания PR по изменениям",
+		Long: `Генерирует по diff сообщение коммита в формате Conventional Commits и
+описание Pull Request: краткое описание, области риска и заметки по проверке.
+Источник изменений:
+- Подготовленные к коммиту изменения (--staged, по умолчанию)
+- Диапазон коммитов (--range или --from --to)
+- Merge Request (--mr, --target): изменения ветки с момента ответвления от целевой
+
+--write-commit-msg записывает сообщение в файл сообщения коммита; используется
+хуком prepare-commit-msg (miniReviewer hook install --hooks prepare-commit-msg).`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("format") {
+				format = viper.GetString("describe.format")
+			}
+			runDescribe(revRange, from, to, target, commitMsgFile, format, output, staged, mr)
+		},
+	}
+
+	cmd.Flags().BoolVar(&staged, "staged", false, "описать подготовленные к коммиту изменения (по умолчанию)")
+	cmd.Flags().StringVar(&revRange, "range", "", "диапазон коммитов в формате git (например main..HEAD)")
+	cmd.Flags().StringVar(&from, "from", "", "начальный коммит или ветка (не включается)")
+	cmd.Flags().StringVar(&to, "to", "", "конечный коммит или ветка (по умолчанию HEAD)")
+	cmd.Flags().BoolVar(&mr, "mr", false, "описать Merge Request (изменения относительно merge-base с основной веткой)")
+	cmd.Flags().StringVar(&target, "target", "", "целевая ветка Merge Request, например origin/develop (включает --mr)")
+	cmd.Flags().StringVar(&commitMsgFile, "write-commit-msg", "", "записать сообщение коммита в файл (например .git/COMMIT_EDITMSG)")
+	cmd.Flags().StringVar(&format, "format", "markdown", "формат описания PR (markdown, json) (по умолчанию describe.format)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для описания PR")
+
+	return cmd
+}
+
+// runDescribe генерирует описание выбранных изменений
+func runDescribe(revRange, from, to, target, commitMsgFile, format, output string, staged, mr bool) {
+	verbose := viper.GetBool("verbose")
+
+	gitClient := validateGitRepository(verbose)
+
+	var changes []ChangeInfo
+	var commits []git.Commit
+	switch {
+	case mr || target != "":
+		if staged || revRange != "" || from != "" {
+			fmt.Println("❌ --mr нельзя сочетать с --staged, --range и --from")
+			os.Exit(1)
+		}
+		target = resolveMRTarget(gitClient, target)
+		changes = getMRChanges(gitClient, target, verbose)
+		commits = getDescribedCommits(gitClient, target+"..HEAD")
+	case revRange != "" || from != "":
+		if staged {
+			fmt.Println("❌ --staged нельзя сочетать с --range и --from")
+			os.Exit(1)
+		}
+		revRange = resolveCommitRange(revRange, from, to)
+		rangeFrom, rangeTo := splitCommitRange(gitClient, revRange)
+		changes = getRangeChanges(gitClient, rangeFrom, rangeTo, verbose)
+		commits = getDescribedCommits(gitClient, revRange)
+	default:
+		changes = getStagedChanges(gitClient, verbose)
+	}
+
+	var files []git.FileDiff
+	if len(changes) > 0 {
+		files = filterDescribedFiles(git.ParseDiff(changes[0].Diff), verbose)
+	}
+	if len(files) == 0 {
+		fmt.Println("ℹ️  Нет изменений для описания")
+		return
+	}
+
+	fmt.Printf("🧠 Генерация описания (модель: %s)...\n", viper.GetString("ollama.default_model"))
+
+	description, err := analyzer.NewChangeDescriber().Describe(files, commits)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	message := analyzer.CommitMessage(description)
+
+	if commitMsgFile != "" {
+		written, err := writeCommitMessageFile(commitMsgFile, message)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		if written {
+			fmt.Printf("✅ Сообщение коммита записано в %s: %s\n", commitMsgFile, analyzer.CommitHeader(description))
+		} else {
+			fmt.Println("ℹ️  Сообщение коммита уже задано, оставлено без изменений")
+		}
+		return
+	}
+
+	printDescription(description, message)
+
+	if output != "" {
+		reportGen := reporter.NewReporter(&types.ReportOptions{Format: format})
+		report, err := reportGen.GenerateDescriptionReport(description, format)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		if err := reportGen.SaveReport(report, output); err != nil {
+			fmt.Printf("❌ Ошибка сохранения описания: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n💾 Описание PR сохранено в: %s\n", output)
+	}
+}
+
+// splitCommitRange возвращает границы диапазона коммитов для diff. Для диапазона
+// a...b начальной границей считается merge-base, для одной ревизии - ее родитель.
+func splitCommitRange(gitClient *git.Client, revRange string) (string, string) {
+	if from, to, found := strings.Cut(revRange, "..."); found {
+		if to == "" {
+			to = "HEAD"
+		}
+		mergeBase, err := gitClient.GetMergeBase(from, to)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		return mergeBase, to
+	}
+	if from, to, found := strings.Cut(revRange, ".."); found {
+		if to == "" {
+			to = "HEAD"
+		}
+		return from, to
+	}
+	return revRange + "^", revRange
+}
+
+// filterDescribedFiles исключает файлы, игнорируемые по analysis.ignore_patterns,
+// .gitignore и .miniReviewerignore
+func filterDescribedFiles(files []git.FileDiff, verbose bool) []git.FileDiff {
+	ignore := filesystem.NewIgnoreMatcher(".", viper.GetStringSlice("analysis.ignore_patterns"))
+
+	var described []git.FileDiff
+	for _, file := range files {
+		if pattern := ignore.Match(file.Path(), false); pattern != "" {
+			if verbose {
+				fmt.Printf("⏭️  Пропускаю %s: игнорируется паттерном %s\n", file.Path(), pattern)
+			}
+			continue
+		}
+		described = append(described, file)
+	}
+	return described
+}
+
+// getDescribedCommits возвращает коммиты описываемого диапазона для контекста описания
+func getDescribedCommits(gitClient *git.Client, revRange string) []git.Commit {
+	commits, err := gitClient.GetCommits(revRange, viper.GetInt("git.max_commit_history"), true)
+	if err != nil {
+		fmt.Printf("⚠️  Не удалось получить список коммитов: %v\n", err)
+	}
+	return commits
+}
+
+// printDescription выводит сообщение коммита и описание PR
+func printDescription(description *types.ChangeDescription, message string) {
+	fmt.Println("\n📝 Сообщение коммита:")
+	for _, line := range strings.Split(message, "\n") {
+		if line == "" {
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("  %s\n", line)
+	}
+
+	fmt.Printf("\n📋 Описание PR: %s\n", description.Title)
+	fmt.Printf("\n%s\n", description.Summary)
+	if description.Breaking {
+		fmt.Println("\n💥 Изменения ломают обратную совместимость")
+	}
+
+	fmt.Println("\n⚠️  Области риска:")
+	printDescriptionItems(description.RiskAreas)
+
+	fmt.Println("\n🧪 Как проверить:")
+	printDescriptionItems(description.TestingNotes)
+}
+
+// printDescriptionItems выводит пункты описания
+func printDescriptionItems(items []string) {
+	if len(items) == 0 {
+		fmt.Println("  —")
+		return
+	}
+	for _, item := range items {
+		fmt.Printf("  • %s\n", item)
+	}
+}
+
+// writeCommitMessageFile записывает сообщение в начало файла сообщения коммита,
+// сохраняя комментарии git. Если в файле уже есть сообщение (git commit -m,
+// merge, amend), файл не изменяется и возвращается false.
+func writeCommitMessageFile(path, message string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("ошибка чтения файла сообщения коммита %s: %v", path, err)
+	}
+
+	for _, line := range strings.Split(string(existing), "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return false, nil
+		}
+	}
+
+	content := message + "\n"
+	if len(existing) > 0 {
+		content += "\n" + strings.TrimLeft(string(existing), "\n")
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("ошибка записи файла сообщения коммита %s: %v", path, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/fixer"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// FixCmd команда для автоматического исправления найденных проблем
+func FixCmd() *cobra.Command {
+	var input, path, output, minSeverity string
+	var apply, force bool
+
+	cmd := &cobra.Command{
+		Use:   "fix",
+		Short: "AI-исправление найденных проблем",
+		Long: `Запрашивает у AI (Ollama) конкретные исправления для найденных проблем
+и показывает их в виде unified diff.
+
+Проблемы берутся из JSON файла с результатами (--input) или находятся
+анализом качества (--path). С флагом --apply исправления записываются
+в файлы, если файл не изменился с момента анализа. Go файлы после
+исправления проверяются go/parser и откатываются при синтаксической ошибке.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runFix(input, path, output, minSeverity, apply, force)
+		},
+	}
+
+	cmd.Flags().StringVarP(&input, "input", "i", "", "JSON файл с результатами анализа")
+	cmd.Flags().StringVar(&path, "path", ".", "путь для анализа, если не указан --input")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для сохранения diff")
+	cmd.Flags().StringVar(&minSeverity, "min-severity", "low", "минимальная важность исправляемых проблем (low, medium, high, critical)")
+	cmd.Flags().BoolVar(&apply, "apply", false, "применить исправления к файлам")
+	cmd.Flags().BoolVar(&force, "force", false, "применять исправления даже без хеша файла в результатах")
+
+	return cmd
+}
+
+// fixTarget проблемы одного файла, которые нужно исправить
+type fixTarget struct {
+	File   string
+	Hash   string
+	Issues []types.Issue
+}
+
+// runFix выполняет генерацию и применение исправлений
+func runFix(input, path, output, minSeverity string, apply, force bool) {
+	verbose := viper.GetBool("verbose")
+
+	fmt.Println("🛠️  Запуск AI-исправления...")
+	fmt.Printf("Модель: %s\n", viper.GetString("ollama.default_model"))
+
+	results := loadFixResults(input, path, verbose)
+	targets := collectFixTargets(results, minSeverity)
+	if len(targets) == 0 {
+		fmt.Println("✅ Нет проблем для исправления")
+		return
+	}
+
+	fmt.Printf("Файлов с проблемами: %d\n", len(targets))
+
+	fix := fixer.NewFixer(viper.GetInt("fix.context_lines"))
+	var allDiffs strings.Builder
+	applied := 0
+
+	for _, target := range targets {
+		diff, patches := proposeFileFixes(fix, target, verbose)
+		if len(patches) == 0 {
+			continue
+		}
+		allDiffs.WriteString(diff)
+
+		if !apply {
+			continue
+		}
+
+		if target.Hash == "" && !force {
+			fmt.Printf("⚠️  %s: нет хеша файла в результатах, невозможно проверить изменения (используйте --force)\n", target.File)
+			continue
+		}
+
+		if err := fixer.ApplyPatches(target.File, patches, target.Hash); err != nil {
+			fmt.Printf("❌ %s: %v\n", target.File, err)
+			continue
+		}
+
+		applied += len(patches)
+		fmt.Printf("✅ %s: применено исправлений: %d\n", target.File, len(patches))
+	}
+
+	if output != "" {
+		if err := os.WriteFile(output, []byte(allDiffs.String()), 0644); err != nil {
+			fmt.Printf("❌ Ошибка сохранения diff: %v\n", err)
+		} else {
+			fmt.Printf("\n💾 Diff сохранен в: %s\n", output)
+		}
+	}
+
+	if apply {
+		fmt.Printf("\n📈 Применено исправлений: %d\n", applied)
+	}
+
+	fmt.Println("✅ Исправление завершено")
+}
+
+// loadFixResults загружает результаты из файла или выполняет анализ качества
+func loadFixResults(input, path string, verbose bool) []*types.CodeAnalysisResult {
+	if input != "" {
+		results, err := loadResultsFromFile(input)
+		if err != nil {
+			fmt.Printf("❌ Ошибка чтения результатов: %v\n", err)
+			os.Exit(1)
+		}
+		if verbose {
+			fmt.Printf("📄 Загружено результатов: %d\n", len(results))
+		}
+		return results
+	}
+
+	groups, err := getFilesForAnalysis(getAnalysisPath(path), nil, verbose)
+	if err != nil {
+		fmt.Printf("❌ Ошибка поиска файлов: %v\n", err)
+		os.Exit(1)
+	}
+
+	return analyzeModuleFiles(groups, analyzer.GranularityFile, verbose)
+}
+
+// loadResultsFromFile читает результаты анализа из JSON файла.
+// Поддерживаются массив результатов и JSON отчет команды report.
+func loadResultsFromFile(filename string) ([]*types.CodeAnalysisResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*types.CodeAnalysisResult
+	if err := json.Unmarshal(data, &results); err == nil {
+		return results, nil
+	}
+
+	var report struct {
+		Results []*types.CodeAnalysisResult `json:"results"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("неизвестный формат файла %s: %v", filename, err)
+	}
+
+	return report.Results, nil
+}
+
+// collectFixTargets группирует проблемы по файлам и фильтрует по важности
+func collectFixTargets(results []*types.CodeAnalysisResult, minSeverity string) []*fixTarget {
+	targets := make(map[string]*fixTarget)
+	minRank := analyzer.SeverityRank(minSeverity)
+
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if analyzer.SeverityRank(issue.Severity) < minRank {
+				continue
+			}
+
+			file := issue.File
+			if file == "" {
+				file = result.File
+			}
+			if _, err := os.Stat(file); err != nil {
+				continue
+			}
+
+			target, exists := targets[file]
+			if !exists {
+				target = &fixTarget{File: file}
+				targets[file] = target
+			}
+			if result.File == file && result.FileHash != "" {
+				target.Hash = result.FileHash
+			}
+			target.Issues = append(target.Issues, issue)
+		}
+	}
+
+	var sorted []*fixTarget
+	for _, target := range targets {
+		sort.SliceStable(target.Issues, func(i, j int) bool { return target.Issues[i].Line < target.Issues[j].Line })
+		sorted = append(sorted, target)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].File < sorted[j].File })
+
+	return sorted
+}
+
+// proposeFileFixes запрашивает исправления для проблем одного файла и выводит diff
+func proposeFileFixes(fix *fixer.Fixer, target *fixTarget, verbose bool) (string, []*fixer.Patch) {
+	content, err := os.ReadFile(target.File)
+	if err != nil {
+		fmt.Printf("⚠️  Ошибка чтения %s: %v\n", target.File, err)
+		return "", nil
+	}
+
+	if target.Hash != "" && analyzer.ContentHash(content) != target.Hash {
+		fmt.Printf("⚠️  %s изменился с момента анализа, пропускаю\n", target.File)
+		return "", nil
+	}
+
+	fileLines := fixer.FileLines(content)
+	var diff strings.Builder
+	var patches []*fixer.Patch
+
+	for i, issue := range target.Issues {
+		fmt.Printf("\n🔧 [%d/%d] %s (строка %d): %s\n", i+1, len(target.Issues), target.File, issue.Line, issue.Message)
+
+		patch, err := fix.ProposeFix(target.File, content, issue)
+		if err != nil {
+			fmt.Printf("   ⚠️  Не удалось получить исправление: %v\n", err)
+			continue
+		}
+
+		if patch.IsNoop() {
+			fmt.Println("   ℹ️  Модель не предложила изменений")
+			continue
+		}
+
+		if overlapsAny(patch, patches) {
+			fmt.Println("   ⚠️  Исправление пересекается с предыдущим, пропускаю")
+			continue
+		}
+
+		patchDiff := patch.UnifiedDiff(fileLines, 3)
+		fmt.Println(patchDiff)
+		if verbose && patch.Explanation != "" {
+			fmt.Printf("   🧠 %s\n", patch.Explanation)
+		}
+
+		diff.WriteString(patchDiff)
+		patches = append(patches, patch)
+	}
+
+	return diff.String(), patches
+}
+
+// overlapsAny проверяет пересечение патча с уже принятыми
+func overlapsAny(patch *fixer.Patch, accepted []*fixer.Patch) bool {
+	for _, other := range accepted {
+		if patch.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
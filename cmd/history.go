@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/reporter"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// HistoryCmd команда для анализа каждого коммита диапазона с динамикой качества
+func HistoryCmd() *cobra.Command {
+	var from, to, format, output string
+	var limit, parallel int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Анализ каждого коммита истории с динамикой качества",
+		Long: `Анализирует изменения каждого коммита диапазона (без merge-коммитов) и строит
+динамику оценки и количества проблем по коммитам, чтобы найти коммит, который
+ухудшил качество. Отчет (--output) содержит график динамики.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("limit") {
+				limit = viper.GetInt("git.max_commit_history")
+			}
+			if !cmd.Flags().Changed("parallel") {
+				parallel = viper.GetInt("history.parallel")
+			}
+			runHistoryAnalysis(resolveCommitRange("", from, to), limit, parallel, format, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "начальный коммит, тег или ветка (не включается)")
+	cmd.Flags().StringVar(&to, "to", "", "конечный коммит или ветка (по умолчанию HEAD)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "максимальное количество последних коммитов (по умолчанию git.max_commit_history)")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "количество коммитов, анализируемых одновременно (по умолчанию history.parallel)")
+	cmd.Flags().StringVar(&format, "format", "html", "формат отчета (html, json, markdown)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для отчета с графиком динамики")
+
+	return cmd
+}
+
+// runHistoryAnalysis анализирует коммиты диапазона и выводит динамику
+func runHistoryAnalysis(revRange string, limit, parallel int, format, output string) {
+	verbose := viper.GetBool("verbose")
+
+	gitClient := validateGitRepository(verbose)
+
+	commits, err := gitClient.GetCommitHistory(revRange, limit)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("📜 Анализ истории коммитов...")
+	fmt.Printf("Диапазон: %s\n", revRange)
+	fmt.Printf("Модель: %s\n", viper.GetString("ollama.default_model"))
+
+	if len(commits) == 0 {
+		fmt.Println("✅ Нет коммитов для анализа")
+		return
+	}
+
+	if parallel < 1 {
+		parallel = 1
+	}
+	fmt.Printf("Коммитов: %d, одновременно: %d\n", len(commits), parallel)
+
+	// Модули выбираются до запуска параллельного анализа коммитов
+	points := analyzeCommitHistory(gitClient, commits, selectedModules(verbose), parallel, verbose)
+	analyzer.MarkRegressions(points, viper.GetInt("history.regression_score_drop"))
+
+	printHistoryTimeline(points)
+
+	if output != "" {
+		reportGen := reporter.NewReporter(&types.ReportOptions{Format: format})
+		report, err := reportGen.GenerateHistoryReport(points, revRange, format)
+		if err != nil {
+			fmt.Printf("❌ Ошибка генерации отчета: %v\n", err)
+			os.Exit(1)
+		}
+		if err := reportGen.SaveReport(report, output); err != nil {
+			fmt.Printf("❌ Ошибка сохранения отчета: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n💾 Отчет сохранен в: %s\n", output)
+	}
+
+	fmt.Println("\n✅ Анализ истории завершен")
+}
+
+// analyzeCommitHistory анализирует изменения каждого коммита, до parallel коммитов
+// одновременно. Порядок точек совпадает с порядком коммитов. Отпечатки проблем
+// строятся по содержимому файлов в самом коммите, а не в рабочей копии.
+func analyzeCommitHistory(gitClient *git.Client, commits []git.Commit, selected []types.WorkspaceModule, parallel int, verbose bool) []types.CommitTrendPoint {
+	points := make([]types.CommitTrendPoint, len(commits))
+	ignorePatterns := viper.GetStringSlice("analysis.ignore_patterns")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, parallel)
+	done := 0
+
+	for i, commit := range commits {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, commit git.Commit) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var results []*types.CodeAnalysisResult
+			diff, err := gitClient.GetCommitDiff(commit.Hash)
+			if err != nil {
+				fmt.Printf("⚠️  Ошибка получения diff для коммита %s: %v\n", commit.ShortHash, err)
+			} else {
+				results = performAnalysis(gitClient, nil, []ChangeInfo{{
+					Type:        AnalysisSpecificCommits,
+					Identifier:  commit.Hash,
+					Diff:        diff,
+					Description: fmt.Sprintf("Коммит %s: %s", commit.ShortHash, commit.Subject),
+					Revision:    commit.Hash,
+				}}, ignorePatterns, selected, verbose)
+			}
+
+			points[i] = analyzer.NewTrendPoint(commit, results)
+
+			mu.Lock()
+			done++
+			fmt.Printf("🔄 [%d/%d] %s %s\n", done, len(commits), commit.ShortHash, commit.Subject)
+			mu.Unlock()
+		}(i, commit)
+	}
+
+	wg.Wait()
+	return points
+}
+
+// printHistoryTimeline выводит оценку и количество проблем по коммитам
+func printHistoryTimeline(points []types.CommitTrendPoint) {
+	fmt.Printf("\n📈 Динамика по коммитам (оценка | проблемы):\n")
+
+	for _, point := range points {
+		if point.Skipped {
+			fmt.Printf("  %s %s  %-20s   —  | нет анализируемых изменений  %s\n",
+				point.Date.Format("2006-01-02"), point.ShortHash, "", point.Subject)
+			continue
+		}
+
+		filled := point.Score / 5
+		if filled < 0 {
+			filled = 0
+		} else if filled > 20 {
+			filled = 20
+		}
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", 20-filled)
+		marker := ""
+		if point.Regression {
+			marker = " 📉"
+		}
+
+		var severities []string
+		for _, severity := range []string{"critical", "high", "medium", "low"} {
+			if count := point.BySeverity[severity]; count > 0 {
+				severities = append(severities, fmt.Sprintf("%s: %d", severity, count))
+			}
+		}
+		issues := fmt.Sprintf("%d", point.Issues)
+		if len(severities) > 0 {
+			issues += " (" + strings.Join(severities, ", ") + ")"
+		}
+
+		fmt.Printf("  %s %s  %s %3d | %s  %s%s\n",
+			point.Date.Format("2006-01-02"), point.ShortHash, bar, point.Score, issues, point.Subject, marker)
+	}
+
+	var regressions []string
+	for _, point := range points {
+		if point.Regression {
+			regressions = append(regressions, point.ShortHash)
+		}
+	}
+	if len(regressions) > 0 {
+		fmt.Printf("\n📉 Коммиты, ухудшившие качество: %s\n", strings.Join(regressions, ", "))
+	}
+}
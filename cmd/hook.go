@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/hooks"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// HookCmd команда для управления git хуками pre-commit, pre-push и prepare-commit-msg
+func HookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Управление git хуками pre-commit, pre-push и prepare-commit-msg",
+		Long: `Устанавливает git хуки, которые запускают analyze перед коммитом
+(подготовленные изменения) и перед push (отправляемые коммиты) и блокируют
+их при проблемах заданной важности. Хук prepare-commit-msg заполняет сообщение
+коммита, сгенерированное командой describe. Хуки записываются в .git/hooks или в
+core.hooksPath; существующие хуки сохраняются и вызываются первыми.
+Пропустить проверку: ` + hooks.SkipEnv + `=1 git commit ...`,
+	}
+
+	cmd.AddCommand(hookInstallCmd())
+	cmd.AddCommand(hookUninstallCmd())
+	cmd.AddCommand(hookStatusCmd())
+
+	return cmd
+}
+
+// hookInstallCmd команда установки хуков
+func hookInstallCmd() *cobra.Command {
+	var kinds []string
+	var failOn string
+	var timeBudget time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Установить хуки",
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("fail-on") {
+				failOn = viper.GetString("hooks.fail_on")
+			}
+			if !cmd.Flags().Changed("time-budget") {
+				timeBudget = viper.GetDuration("hooks.time_budget")
+			}
+			if !cmd.Flags().Changed("hooks") {
+				kinds = viper.GetStringSlice("hooks.install")
+			}
+			runHookInstall(kinds, failOn, timeBudget)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&kinds, "hooks", []string{hooks.PreCommit, hooks.PrePush}, "устанавливаемые хуки: "+strings.Join(hooks.Kinds(), ", ")+" (по умолчанию hooks.install)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "high", "важность проблем, блокирующая коммит или push (по умолчанию hooks.fail_on)")
+	cmd.Flags().DurationVar(&timeBudget, "time-budget", 5*time.Minute, "время анализа, после которого проверка пропускается (по умолчанию hooks.time_budget)")
+
+	return cmd
+}
+
+// hookUninstallCmd команда удаления хуков
+func hookUninstallCmd() *cobra.Command {
+	var kinds []string
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Удалить хуки и восстановить сохраненные",
+		Run: func(cmd *cobra.Command, args []string) {
+			runHookUninstall(kinds)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&kinds, "hooks", hooks.Kinds(), "удаляемые хуки")
+
+	return cmd
+}
+
+// hookStatusCmd команда просмотра состояния хуков
+func hookStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Показать состояние хуков",
+		Run: func(cmd *cobra.Command, args []string) {
+			runHookStatus()
+		},
+	}
+}
+
+// runHookInstall устанавливает хуки
+func runHookInstall(kinds []string, failOn string, timeBudget time.Duration) {
+	validateHookKinds(kinds)
+	if analyzer.SeverityRank(failOn) == 0 {
+		fmt.Printf("❌ Неизвестная важность для --fail-on: %s (low, medium, high, critical)\n", failOn)
+		os.Exit(1)
+	}
+	if timeBudget <= 0 {
+		fmt.Println("❌ --time-budget должен быть положительным")
+		os.Exit(1)
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		fmt.Printf("❌ Ошибка определения пути к miniReviewer: %v\n", err)
+		os.Exit(1)
+	}
+
+	dir := getHooksDir()
+	options := hooks.Options{Binary: binary, FailOn: failOn, TimeBudget: timeBudget.String()}
+
+	fmt.Printf("🪝 Установка хуков в %s\n", dir)
+	for _, kind := range kinds {
+		status, err := hooks.Install(dir, kind, options)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		if status.Chained {
+			fmt.Printf("  ✅ %s (существующий хук сохранен и вызывается первым)\n", kind)
+		} else {
+			fmt.Printf("  ✅ %s\n", kind)
+		}
+	}
+
+	fmt.Printf("Блокируются проблемы важности %s и выше, бюджет времени %s\n", failOn, timeBudget)
+	fmt.Printf("Пропустить проверку: %s=1 git commit ...\n", hooks.SkipEnv)
+}
+
+// runHookUninstall удаляет хуки
+func runHookUninstall(kinds []string) {
+	validateHookKinds(kinds)
+	dir := getHooksDir()
+
+	for _, kind := range kinds {
+		before := hooks.GetStatus(dir, kind)
+		if _, err := hooks.Uninstall(dir, kind); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+			continue
+		}
+
+		switch {
+		case !before.Installed:
+			fmt.Printf("  ℹ️  %s не установлен\n", kind)
+		case before.Chained:
+			fmt.Printf("  🗑️  %s удален, сохраненный хук восстановлен\n", kind)
+		default:
+			fmt.Printf("  🗑️  %s удален\n", kind)
+		}
+	}
+}
+
+// runHookStatus выводит состояние хуков
+func runHookStatus() {
+	dir := getHooksDir()
+	fmt.Printf("🪝 Директория хуков: %s\n", dir)
+
+	for _, kind := range hooks.Kinds() {
+		status := hooks.GetStatus(dir, kind)
+		switch {
+		case status.Installed && status.Chained:
+			fmt.Printf("  ✅ %s: установлен, вызывает сохраненный хук %s\n", kind, status.ChainedPath())
+		case status.Installed:
+			fmt.Printf("  ✅ %s: установлен\n", kind)
+		case status.Foreign:
+			fmt.Printf("  ⚠️  %s: установлен другой хук\n", kind)
+		default:
+			fmt.Printf("  ❌ %s: не установлен\n", kind)
+		}
+	}
+}
+
+// getHooksDir возвращает директорию хуков текущего репозитория
+func getHooksDir() string {
+	gitClient := validateGitRepository(viper.GetBool("verbose"))
+
+	dir, err := gitClient.GetHooksDir()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	return dir
+}
+
+// validateHookKinds проверяет имена хуков
+func validateHookKinds(kinds []string) {
+	for _, kind := range kinds {
+		if err := hooks.ValidateKind(kind); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// HotspotsCmd команда для поиска часто изменяемых и сложных файлов
+func HotspotsCmd() *cobra.Command {
+	var since, path, output string
+	var ignore []string
+	var top int
+	var analyze bool
+
+	cmd := &cobra.Command{
+		Use:   "hotspots",
+		Short: "Поиск горячих точек: часто изменяемых и сложных файлов",
+		Long: `Собирает по git log --numstat частоту изменений, количество авторов и объем
+изменений каждого файла за период, сочетает их с размером и сложностью файла
+и ранжирует файлы по риску. С --analyze самые рискованные файлы отправляются
+на AI-анализ качества.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("since") {
+				since = viper.GetString("hotspots.since")
+			}
+			if !cmd.Flags().Changed("top") {
+				top = viper.GetInt("hotspots.top")
+			}
+			if !cmd.Flags().Changed("analyze") {
+				analyze = viper.GetBool("hotspots.analyze")
+			}
+			runHotspotsAnalysis(since, path, output, ignore, top, analyze)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "6 months ago", "период истории в формате git (по умолчанию hotspots.since)")
+	cmd.Flags().StringVar(&path, "path", ".", "учитывать только файлы в этом пути")
+	cmd.Flags().IntVar(&top, "top", 10, "количество выводимых файлов (по умолчанию hotspots.top)")
+	cmd.Flags().BoolVar(&analyze, "analyze", false, "отправить top файлов на AI-анализ качества (по умолчанию hotspots.analyze)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
+	cmd.Flags().StringArrayVar(&ignore, "ignore", []string{}, "паттерны для игнорирования")
+
+	return cmd
+}
+
+// runHotspotsAnalysis ранжирует файлы по риску и при необходимости анализирует top файлов
+func runHotspotsAnalysis(since, path, output string, ignore []string, top int, analyze bool) {
+	verbose := viper.GetBool("verbose")
+
+	gitClient := validateGitRepository(verbose)
+
+	fmt.Println("🔥 Поиск горячих точек...")
+	fmt.Printf("Период: %s\n", since)
+
+	commits, err := gitClient.GetCommitsSince(since)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Коммитов за период: %d\n", len(commits))
+
+	ignoreMatcher := filesystem.NewIgnoreMatcher(".", append(viper.GetStringSlice("analysis.ignore_patterns"), ignore...))
+	prefix := filepath.ToSlash(filepath.Clean(path))
+	selected := selectedModules(verbose)
+
+	var hotspots []*types.Hotspot
+	for file, hotspot := range analyzer.CollectChurn(commits) {
+		if reason := skipHotspotReason(file, prefix, ignoreMatcher, selected); reason != "" {
+			if verbose {
+				fmt.Printf("  ⏭️  %s: %s\n", file, reason)
+			}
+			continue
+		}
+
+		// Удаленные и перемещенные с тех пор файлы не учитываются
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		// Сгенерированный и сторонний код не поддерживается вручную
+		if reason, _ := filesystem.DetectSkip(file, content); reason != "" {
+			if verbose {
+				fmt.Printf("  ⏭️  %s: %s\n", file, filesystem.SkipReasonName(reason))
+			}
+			continue
+		}
+		analyzer.MeasureFile(hotspot, content)
+		hotspots = append(hotspots, hotspot)
+	}
+
+	if len(hotspots) == 0 {
+		fmt.Println("✅ Измененных за период файлов не найдено")
+		return
+	}
+
+	analyzer.RankHotspots(hotspots)
+	if top > 0 && len(hotspots) > top {
+		hotspots = hotspots[:top]
+	}
+
+	printHotspots(hotspots)
+
+	if analyze {
+		analyzeHotspots(hotspots, verbose)
+	}
+
+	if output != "" {
+		saveHotspots(hotspots, output, verbose)
+	}
+
+	fmt.Println("\n✅ Поиск горячих точек завершен")
+}
+
+// skipHotspotReason возвращает причину, по которой файл не учитывается
+func skipHotspotReason(file, prefix string, ignore *filesystem.IgnoreMatcher, selected []types.WorkspaceModule) string {
+	if prefix != "." && file != prefix && !strings.HasPrefix(file, prefix+"/") {
+		return "вне пути анализа"
+	}
+	if !moduleSelected(selected, file) {
+		return "вне выбранных модулей"
+	}
+	if pattern := ignore.Match(file, false); pattern != "" {
+		return "игнорируется паттерном " + pattern
+	}
+	if _, err := getSingleFileForAnalysis(file); err != nil {
+		return "неподдерживаемый тип файла"
+	}
+	return ""
+}
+
+// analyzeHotspots отправляет файлы на AI-анализ качества и выводит результаты
+func analyzeHotspots(hotspots []*types.Hotspot, verbose bool) {
+	fmt.Printf("\n🧠 AI-анализ качества горячих точек (%d)...\n", len(hotspots))
+
+	files := make([]string, 0, len(hotspots))
+	for _, hotspot := range hotspots {
+		files = append(files, hotspot.File)
+	}
+
+	results := analyzeFiles(viper.GetViper(), files, analyzer.GranularityFile, verbose)
+	byFile := make(map[string]*types.CodeAnalysisResult)
+	for _, result := range results {
+		byFile[result.File] = result
+	}
+	for _, hotspot := range hotspots {
+		hotspot.Analysis = byFile[hotspot.File]
+	}
+
+	printQualityResults(results, verbose)
+}
+
+// printHotspots выводит файлы в порядке убывания риска
+func printHotspots(hotspots []*types.Hotspot) {
+	fmt.Printf("\n🔥 Горячие точки (риск = частота изменений × сложность × авторы):\n")
+	fmt.Printf("  %-4s %-5s %-8s %-7s %-9s %-7s %-10s %s\n", "#", "Риск", "Коммиты", "Авторы", "Изменено", "Строк", "Сложность", "Файл")
+
+	for i, hotspot := range hotspots {
+		fmt.Printf("  %-4d %s%-3d %-8d %-7d %-9d %-7d %-10d %s\n",
+			i+1, getRiskIcon(hotspot.Risk), hotspot.Risk, hotspot.Commits, hotspot.Authors,
+			hotspot.ChurnLines, hotspot.Lines, hotspot.Complexity, hotspot.File)
+	}
+}
+
+// getRiskIcon возвращает иконку уровня риска
+func getRiskIcon(risk int) string {
+	switch {
+	case risk >= 60:
+		return "🔴"
+	case risk >= 30:
+		return "🟡"
+	default:
+		return "🟢"
+	}
+}
+
+// saveHotspots сохраняет горячие точки в JSON файл
+func saveHotspots(hotspots []*types.Hotspot, output string, verbose bool) {
+	if verbose {
+		fmt.Printf("💾 Сохраняю результаты в файл: %s\n", output)
+	}
+
+	data, err := json.MarshalIndent(hotspots, "", "  ")
+	if err == nil {
+		err = os.WriteFile(output, data, 0644)
+	}
+	if err != nil {
+		fmt.Printf("❌ Ошибка сохранения: %v\n", err)
+		return
+	}
+	fmt.Printf("\n💾 Результаты сохранены в: %s\n", output)
+}
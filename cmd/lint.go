@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/lintimport"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ImportLintCmd команда импорта и AI-триажа вывода внешних линтеров
+func ImportLintCmd() *cobra.Command {
+	var inputs []string
+	var format, tool, output string
+	var noAI bool
+
+	cmd := &cobra.Command{
+		Use:   "import-lint",
+		Short: "Импорт замечаний линтеров с AI-триажем",
+		Long: `Читает JSON или checkstyle вывод go vet, staticcheck, eslint и ruff, преобразует
+замечания в проблемы miniReviewer и просит AI объяснить каждое замечание, оценить
+его реальное влияние, предложить исправление и сгруппировать связанные замечания.
+Результат совместим с командами fix и report.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			triage := viper.GetBool("lint.ai_triage") && !noAI
+			runImportLint(append(inputs, args...), format, tool, output, triage)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&inputs, "input", "i", nil, "файл с выводом линтера (можно указать несколько раз)")
+	cmd.Flags().StringVar(&format, "format", lintimport.FormatAuto, "формат вывода линтера (auto, govet, staticcheck, eslint, ruff, checkstyle)")
+	cmd.Flags().StringVar(&tool, "tool", "", "имя линтера для отчета (по умолчанию определяется по формату)")
+	cmd.Flags().BoolVar(&noAI, "no-ai", false, "только импортировать замечания без AI-триажа")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
+
+	return cmd
+}
+
+// runImportLint импортирует замечания линтеров и выполняет AI-триаж
+func runImportLint(inputs []string, format, tool, output string, triage bool) {
+	verbose := viper.GetBool("verbose")
+
+	if len(inputs) == 0 {
+		fmt.Println("❌ Укажите файл с выводом линтера: --input <file>")
+		os.Exit(1)
+	}
+
+	if err := lintimport.ValidateFormat(format); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🧹 Импорт замечаний линтеров...")
+	fmt.Printf("Модель: %s\n", viper.GetString("ollama.default_model"))
+	fmt.Printf("AI-триаж: %t\n", triage)
+
+	results, err := loadLintResults(inputs, format, tool, triage, verbose)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("✅ Линтеры не нашли замечаний")
+		return
+	}
+
+	analyzer.AssignFingerprints(results)
+
+	for _, result := range results {
+		analyzer.PrintFileIssues(result, verbose)
+	}
+	printLintGroups(results)
+	analyzer.PrintStatistics(results, verbose)
+
+	if output != "" {
+		if err := analyzer.SaveResultsToFile(results, output); err != nil {
+			fmt.Printf("❌ Ошибка сохранения: %v\n", err)
+		} else {
+			fmt.Printf("\n💾 Результаты импорта сохранены в: %s\n", output)
+		}
+	}
+
+	fmt.Println("✅ Импорт замечаний линтеров завершен")
+}
+
+// loadLintResults читает файлы с выводом линтеров, группирует замечания по файлам
+// и при triage дополняет их объяснениями модели
+func loadLintResults(inputs []string, format, tool string, triage, verbose bool) ([]*types.CodeAnalysisResult, error) {
+	var issues []types.Issue
+	for _, input := range inputs {
+		findings, err := lintimport.ParseFile(input, format, tool)
+		if err != nil {
+			return nil, err
+		}
+
+		if verbose {
+			fmt.Printf("📄 %s: замечаний %d\n", input, len(findings))
+		}
+
+		for _, finding := range findings {
+			issues = append(issues, finding.ToIssue("."))
+		}
+	}
+
+	results := analyzer.LintResults(issues)
+	fmt.Printf("📋 Замечаний: %d в %d файлах\n", len(issues), len(results))
+
+	if triage {
+		triager := analyzer.NewLintTriager()
+		for i, result := range results {
+			fmt.Printf("🧠 [%d/%d] Триаж: %s (%d)\n", i+1, len(results), result.File, len(result.Issues))
+			triager.TriageResult(result, verbose)
+		}
+	}
+
+	return results, nil
+}
+
+// mergeLintResults добавляет замечания линтеров к результатам анализа тех же файлов
+func mergeLintResults(results, lintResults []*types.CodeAnalysisResult, deduplicator *analyzer.Deduplicator) []*types.CodeAnalysisResult {
+	byFile := make(map[string]*types.CodeAnalysisResult)
+	for _, result := range results {
+		byFile[filepath.Clean(result.File)] = result
+	}
+
+	for _, lintResult := range lintResults {
+		result, exists := byFile[lintResult.File]
+		if !exists {
+			results = append(results, lintResult)
+			byFile[lintResult.File] = lintResult
+			continue
+		}
+
+		result.Issues = append(result.Issues, lintResult.Issues...)
+		if deduplicator != nil {
+			deduplicator.DeduplicateResult(result)
+		}
+		result.Score = 100 - len(result.Issues)*10
+		if result.Score < 0 {
+			result.Score = 0
+		}
+	}
+
+	return results
+}
+
+// printLintGroups выводит группы связанных замечаний, выделенные AI
+func printLintGroups(results []*types.CodeAnalysisResult) {
+	groups := make(map[string][]string)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if issue.Group == "" {
+				continue
+			}
+			groups[issue.Group] = append(groups[issue.Group], fmt.Sprintf("%s:%d", result.File, issue.Line))
+		}
+	}
+
+	if len(groups) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(groups[names[i]]) != len(groups[names[j]]) {
+			return len(groups[names[i]]) > len(groups[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Printf("\n🗂️  Группы связанных замечаний:\n")
+	for _, name := range names {
+		fmt.Printf("  📌 %s (%d): %s\n", name, len(groups[name]), strings.Join(groups[name], ", "))
+	}
+}
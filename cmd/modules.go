@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"miniReviewer/internal/depgraph"
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// moduleFiles файлы одного модуля монорепозитория
+type moduleFiles struct {
+	Module *types.WorkspaceModule // nil - файлы вне модулей
+	Files  []string
+}
+
+// workspaceModules модули проекта; определяются один раз при первом обращении,
+// в том числе из параллельно анализируемых коммитов истории
+var (
+	workspaceModules []types.WorkspaceModule
+	detectModules    sync.Once
+)
+
+// detectWorkspaceModules возвращает модули монорепозитория, найденные в текущей
+// директории (корне репозитория). При workspace.enabled: false модули не ищутся.
+func detectWorkspaceModules(verbose bool) []types.WorkspaceModule {
+	detectModules.Do(func() {
+		if !viper.GetBool("workspace.enabled") {
+			return
+		}
+
+		modules, err := filesystem.NewScanner(viper.GetStringSlice("analysis.ignore_patterns"), 0).DetectModules(".")
+		if err != nil {
+			fmt.Printf("⚠️  Не удалось определить модули проекта: %v\n", err)
+			return
+		}
+		workspaceModules = modules
+
+		if verbose && len(modules) > 1 {
+			fmt.Printf("📦 Модулей в проекте: %d\n", len(modules))
+			for _, module := range modules {
+				printWorkspaceModule(module)
+			}
+		}
+	})
+	return workspaceModules
+}
+
+// printWorkspaceModule выводит модуль
+func printWorkspaceModule(module types.WorkspaceModule) {
+	details := module.Kind
+	if module.Workspace != "" {
+		details += ", " + module.Workspace
+	}
+	fmt.Printf("  📦 %s: %s (%s)\n", module.Name, module.Path, details)
+}
+
+// selectedModules возвращает модули, выбранные флагом --module по имени или пути.
+// Без флага возвращает nil, неизвестный модуль завершает команду с ошибкой.
+func selectedModules(verbose bool) []types.WorkspaceModule {
+	names := viper.GetStringSlice("module")
+	if len(names) == 0 {
+		return nil
+	}
+
+	modules := detectWorkspaceModules(verbose)
+	var selected []types.WorkspaceModule
+	for _, name := range names {
+		module := findWorkspaceModule(modules, name)
+		if module == nil {
+			fmt.Printf("❌ Модуль %q не найден\n", name)
+			if len(modules) > 0 {
+				fmt.Println("Модули проекта:")
+				for _, module := range modules {
+					printWorkspaceModule(module)
+				}
+			}
+			os.Exit(1)
+		}
+		selected = append(selected, *module)
+	}
+	return selected
+}
+
+// findWorkspaceModule находит модуль по имени или пути
+func findWorkspaceModule(modules []types.WorkspaceModule, name string) *types.WorkspaceModule {
+	cleaned := path.Clean(filepath.ToSlash(name))
+	for i := range modules {
+		if modules[i].Name == name || modules[i].Path == cleaned {
+			return &modules[i]
+		}
+	}
+	return nil
+}
+
+// moduleSelected проверяет, относится ли файл к модулям, выбранным флагом --module
+func moduleSelected(selected []types.WorkspaceModule, file string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	module := filesystem.ModuleOf(workspaceModules, file)
+	return module != nil && findWorkspaceModule(selected, module.Path) != nil
+}
+
+// moduleTargets возвращает модули, которые анализируются в пути path, и директории
+// их сканирования. Модуль, в котором находится path, сканируется начиная с path;
+// модули внутри path - целиком; nil означает файлы path вне модулей. С флагом
+// --module остаются только выбранные модули.
+func moduleTargets(path string, verbose bool) ([]*types.WorkspaceModule, []string) {
+	modules := detectWorkspaceModules(verbose)
+	selected := selectedModules(verbose)
+
+	var targets []*types.WorkspaceModule
+	var dirs []string
+
+	owner := filesystem.ModuleOf(modules, path)
+	if len(selected) == 0 || (owner != nil && findWorkspaceModule(selected, owner.Path) != nil) {
+		targets = append(targets, owner)
+		dirs = append(dirs, path)
+	}
+
+	for i := range modules {
+		module := &modules[i]
+		if (owner != nil && module.Path == owner.Path) || !filesystem.ContainsPath(path, module.Path) {
+			continue
+		}
+		if len(selected) > 0 && findWorkspaceModule(selected, module.Path) == nil {
+			continue
+		}
+		targets = append(targets, module)
+		dirs = append(dirs, module.Path)
+	}
+
+	return targets, dirs
+}
+
+// findModuleFiles находит поддерживаемые файлы директории path с разбивкой по
+// модулям. Каждый модуль сканируется со своими настройками (moduleConfig),
+// файлы вложенного модуля относятся только к нему.
+func findModuleFiles(path string, ignore []string, verbose bool) ([]moduleFiles, []types.SkippedFile, error) {
+	targets, dirs := moduleTargets(path, verbose)
+
+	var groups []moduleFiles
+	var skipped []types.SkippedFile
+	for i, module := range targets {
+		config := moduleConfig(module)
+		scanner := newScanner(config, append(config.GetStringSlice("analysis.ignore_patterns"), ignore...))
+		files, err := scanner.FindSupportedFiles(dirs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		skipped = append(skipped, scanner.Skipped()...)
+		if len(files) > 0 {
+			groups = append(groups, moduleFiles{Module: module, Files: files})
+		}
+	}
+
+	return groups, skipped, nil
+}
+
+// getSingleModuleFile проверяет отдельный файл функцией check и относит его к модулю
+func getSingleModuleFile(file string, check func(string) ([]string, error)) ([]moduleFiles, error) {
+	files, err := check(file)
+	if err != nil {
+		return nil, err
+	}
+	return []moduleFiles{{Module: filesystem.ModuleOf(detectWorkspaceModules(false), file), Files: files}}, nil
+}
+
+// findGoModule возвращает корень и путь Go модуля, в который входит path. Для
+// модулей монорепозитория на других языках Go модуль выше по дереву не учитывается.
+func findGoModule(path string) (string, string) {
+	if module := filesystem.ModuleOf(detectWorkspaceModules(false), path); module != nil && module.Kind != filesystem.ModuleGo {
+		return "", ""
+	}
+	return depgraph.FindModule(path)
+}
+
+// moduleTitle возвращает название модуля для вывода
+func moduleTitle(module *types.WorkspaceModule) string {
+	if module == nil {
+		return "файлы вне модулей"
+	}
+	if module.Name == module.Path {
+		return module.Path
+	}
+	return fmt.Sprintf("%s (%s)", module.Name, module.Path)
+}
+
+// moduleFileList объединяет файлы всех модулей
+func moduleFileList(groups []moduleFiles) []string {
+	var files []string
+	for _, group := range groups {
+		files = append(files, group.Files...)
+	}
+	return files
+}
+
+// moduleConfigs собранные настройки модулей по пути модуля; "" - файлы вне модулей
+var (
+	moduleConfigs   = make(map[string]*viper.Viper)
+	moduleConfigsMu sync.Mutex
+)
+
+// moduleConfig возвращает настройки модуля: основную конфигурацию, секцию
+// workspace.modules (по пути или имени модуля) и файл .miniReviewer.yaml в
+// директории модуля, который важнее. Директории вложенных модулей добавляются в
+// analysis.ignore_patterns. Настройки собираются в отдельный экземпляр viper один
+// раз на модуль, основная конфигурация не изменяется, поэтому модули можно
+// анализировать одновременно.
+func moduleConfig(module *types.WorkspaceModule) *viper.Viper {
+	key := ""
+	if module != nil {
+		key = module.Path
+	}
+
+	moduleConfigsMu.Lock()
+	defer moduleConfigsMu.Unlock()
+	if config, ok := moduleConfigs[key]; ok {
+		return config
+	}
+
+	config := viper.New()
+	if err := config.MergeConfigMap(viper.AllSettings()); err != nil {
+		fmt.Printf("⚠️  Ошибка чтения настроек модуля %s: %v\n", moduleTitle(module), err)
+	}
+	for key, value := range moduleConfigOverrides(module) {
+		config.Set(key, value)
+	}
+	moduleConfigs[key] = config
+	return config
+}
+
+// moduleConfigOverrides возвращает настройки, которые модуль переопределяет
+func moduleConfigOverrides(module *types.WorkspaceModule) map[string]interface{} {
+	dir := "."
+	if module != nil {
+		dir = module.Path
+	}
+
+	overrides := make(map[string]interface{})
+	if module != nil {
+		for key, value := range viper.GetStringMap("workspace.modules") {
+			section, ok := value.(map[string]interface{})
+			if ok && (key == strings.ToLower(module.Path) || key == strings.ToLower(module.Name)) {
+				flattenConfig("", section, overrides)
+			}
+		}
+		if module.Path != "." {
+			local, err := readModuleConfig(module.Path)
+			if err != nil {
+				fmt.Printf("⚠️  %v\n", err)
+			}
+			flattenConfig("", local, overrides)
+		}
+	}
+	// Секция workspace и выбор модулей задаются только для всего проекта
+	for key := range overrides {
+		if strings.HasPrefix(key, "workspace.") || key == "module" {
+			delete(overrides, key)
+		}
+	}
+
+	if nested := filesystem.NestedModules(workspaceModules, dir); len(nested) > 0 {
+		patterns := viper.GetStringSlice("analysis.ignore_patterns")
+		if value, ok := overrides["analysis.ignore_patterns"]; ok {
+			patterns = toStringSlice(value)
+		}
+		for _, nestedModule := range nested {
+			patterns = append(patterns, "/"+nestedModule.Path+"/")
+		}
+		overrides["analysis.ignore_patterns"] = patterns
+	}
+	return overrides
+}
+
+// readModuleConfig читает .miniReviewer.yaml из директории модуля
+func readModuleConfig(dir string) (map[string]interface{}, error) {
+	file := filepath.Join(dir, ".miniReviewer.yaml")
+	if _, err := os.Stat(file); err != nil {
+		return nil, nil
+	}
+
+	config := viper.New()
+	config.SetConfigFile(file)
+	if err := config.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения конфигурации модуля %s: %v", file, err)
+	}
+	return config.AllSettings(), nil
+}
+
+// flattenConfig раскладывает вложенные секции конфигурации в ключи вида
+// "quality.max_complexity"
+func flattenConfig(prefix string, section map[string]interface{}, out map[string]interface{}) {
+	for key, value := range section {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenConfig(prefix+key+".", nested, out)
+			continue
+		}
+		out[prefix+key] = value
+	}
+}
+
+// toStringSlice приводит значение списка из конфигурации к []string
+func toStringSlice(value interface{}) []string {
+	switch list := value.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
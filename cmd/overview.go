@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/cache"
+	"miniReviewer/internal/depgraph"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// openSummaryCache открывает кэш промежуточных описаний; performance.enable_caching
+// отключает кэширование полностью
+func openSummaryCache(config *viper.Viper, verbose bool) *cache.Cache {
+	ttl, _ := time.ParseDuration(config.GetString("summary.cache_ttl"))
+
+	summaryCache, err := cache.Open(config.GetString("summary.cache_file"), ttl, config.GetBool("performance.enable_caching"))
+	if err != nil {
+		if verbose {
+			fmt.Printf("⚠️  Кэш описаний недоступен: %v\n", err)
+		}
+		return nil
+	}
+
+	return summaryCache
+}
+
+// buildModuleOverview строит иерархический обзор каталога: описания файлов,
+// затем описания пакетов, с настройками config. Для Go модулей добавляется граф импортов.
+func buildModuleOverview(config *viper.Viper, projectPath string, graph *depgraph.Graph, verbose bool) (*types.ModuleOverview, error) {
+	// Пакеты именуются относительно корня Go модуля, как в графе импортов
+	root, module := findGoModule(projectPath)
+	if root == "" {
+		absolute, err := filepath.Abs(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		root = absolute
+	}
+
+	scanner := newScanner(config, config.GetStringSlice("analysis.ignore_patterns"))
+	files, err := scanner.FindSupportedFiles(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска файлов: %v", err)
+	}
+	printSkippedFiles(scanner.Skipped(), verbose)
+
+	for i, file := range files {
+		if absolute, err := filepath.Abs(file); err == nil {
+			files[i] = absolute
+		}
+	}
+
+	fmt.Printf("🗺️  Строю обзор проекта: %d файлов\n", len(files))
+
+	summarizer := analyzer.NewSummarizer(openSummaryCache(config, verbose), config)
+	overview := summarizer.BuildOverview(root, module, files, verbose)
+	if graph != nil {
+		overview.Dependencies = graph.Render()
+	}
+
+	return overview, nil
+}
+
+// printModuleOverview выводит краткий обзор пакетов
+func printModuleOverview(overview *types.ModuleOverview) {
+	fmt.Printf("\n🗺️  Обзор пакетов (%d):\n", len(overview.Packages))
+	for _, pkg := range overview.Packages {
+		fmt.Printf("  📦 %s (%d файлов): %s\n", pkg.Package, len(pkg.Files), pkg.Purpose)
+		if len(pkg.Dependencies) > 0 {
+			fmt.Printf("     ↳ зависит от: %v\n", pkg.Dependencies)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"miniReviewer/internal/analyzer"
-	"miniReviewer/internal/filesystem"
 	"miniReviewer/internal/types"
 
 	"github.com/spf13/cobra"
@@ -16,7 +15,7 @@ import (
 
 // QualityCmd команда для проверки качества кода
 func QualityCmd() *cobra.Command {
-	var severity, output, path string
+	var severity, output, path, granularity, author string
 	var ignore []string
 
 	cmd := &cobra.Command{
@@ -26,7 +25,7 @@ func QualityCmd() *cobra.Command {
 Анализирует сложность, длину функций, стиль и предлагает улучшения.
 Может анализировать как отдельные файлы, так и целые директории.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runQualityAnalysis(severity, output, path, ignore)
+			runQualityAnalysis(severity, output, path, granularity, author, ignore)
 		},
 	}
 
@@ -34,14 +33,27 @@ func QualityCmd() *cobra.Command {
 	cmd.Flags().StringVar(&severity, "severity", "medium", "уровень важности (low, medium, high, critical)")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
 	cmd.Flags().StringArrayVar(&ignore, "ignore", []string{}, "паттерны для игнорирования")
+	cmd.Flags().StringVar(&granularity, "granularity", analyzer.GranularityFile, "гранулярность анализа (file, function)")
+	cmd.Flags().StringVar(&author, "author", "", "оставить только проблемы в строках автора (имя или email, по git blame)")
 
 	return cmd
 }
 
 // runQualityAnalysis выполняет анализ качества кода
-func runQualityAnalysis(severity, output, path string, ignore []string) {
+func runQualityAnalysis(severity, output, path, granularity, author string, ignore []string) {
 	verbose := viper.GetBool("verbose")
 
+	if err := analyzer.ValidateGranularity(granularity); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	attributor := newAttributor(verbose)
+	if err := validateAuthorFilter(author, attributor); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
 	printQualityHeader(severity, verbose)
 
 	// Определяем путь для анализа
@@ -50,13 +62,14 @@ func runQualityAnalysis(severity, output, path string, ignore []string) {
 		fmt.Printf("📁 Путь для анализа: %s\n", analysisPath)
 	}
 
-	// Получаем список файлов для анализа
-	files, err := getFilesForAnalysis(analysisPath, ignore, verbose)
+	// Получаем список файлов для анализа по модулям
+	groups, err := getFilesForAnalysis(analysisPath, ignore, verbose)
 	if err != nil {
 		fmt.Printf("❌ Ошибка поиска файлов: %v\n", err)
 		os.Exit(1)
 	}
 
+	files := moduleFileList(groups)
 	if len(files) == 0 {
 		fmt.Println("❌ Поддерживаемые файлы не найдены")
 		os.Exit(1)
@@ -69,10 +82,21 @@ func runQualityAnalysis(severity, output, path string, ignore []string) {
 	}
 
 	// Выполняем анализ
-	results := analyzeFiles(files, verbose)
+	results := analyzeModuleFiles(groups, granularity, verbose)
+
+	// Определяем авторов строк с проблемами
+	if attributor != nil {
+		attributor.AttributeResults(results, "")
+		if author != "" {
+			analyzer.FilterResultsByAuthor(results, author)
+			fmt.Printf("👤 Фильтр по автору: %s\n", author)
+		}
+	}
 
 	// Выводим результаты
 	printQualityResults(results, verbose)
+	analyzer.PrintModuleStatistics(results, workspaceModules)
+	analyzer.PrintAuthorStatistics(results)
 
 	// Сохраняем результаты если указан файл
 	if output != "" {
@@ -111,8 +135,8 @@ func getAnalysisPath(path string) string {
 	return "."
 }
 
-// getFilesForAnalysis получает список файлов для анализа
-func getFilesForAnalysis(analysisPath string, ignore []string, verbose bool) ([]string, error) {
+// getFilesForAnalysis получает список файлов для анализа с разбивкой по модулям
+func getFilesForAnalysis(analysisPath string, ignore []string, verbose bool) ([]moduleFiles, error) {
 	ignorePatterns := viper.GetStringSlice("analysis.ignore_patterns")
 	ignorePatterns = append(ignorePatterns, ignore...)
 
@@ -121,15 +145,15 @@ func getFilesForAnalysis(analysisPath string, ignore []string, verbose bool) ([]
 		fmt.Printf("📁 Сканирую %s на поддерживаемые файлы...\n", analysisPath)
 	}
 
-	scanner := filesystem.NewScanner(ignorePatterns, 0)
-
 	// Проверяем, является ли путь файлом
 	if fileInfo, statErr := os.Stat(analysisPath); statErr == nil && !fileInfo.IsDir() {
-		return getSingleFileForAnalysis(analysisPath)
+		return getSingleModuleFile(analysisPath, getSingleFileForAnalysis)
 	}
 
-	// Это директория - ищем поддерживаемые файлы
-	return scanner.FindSupportedFiles(analysisPath)
+	// Это директория - ищем поддерживаемые файлы в каждом модуле
+	groups, skipped, err := findModuleFiles(analysisPath, ignore, verbose)
+	printSkippedFiles(skipped, verbose)
+	return groups, err
 }
 
 // getSingleFileForAnalysis проверяет и возвращает один файл для анализа
@@ -146,10 +170,19 @@ func getSingleFileForAnalysis(filePath string) ([]string, error) {
 	return nil, fmt.Errorf("файл %s не поддерживается. Поддерживаемые расширения: %v", filePath, supportedExtensions)
 }
 
-// analyzeFiles анализирует список файлов
-func analyzeFiles(files []string, verbose bool) []*types.CodeAnalysisResult {
+// analyzeModuleFiles анализирует файлы каждого модуля с его настройками
+func analyzeModuleFiles(groups []moduleFiles, granularity string, verbose bool) []*types.CodeAnalysisResult {
 	var results []*types.CodeAnalysisResult
-	qualityAnalyzer := analyzer.NewQualityAnalyzer()
+	for _, group := range groups {
+		results = append(results, analyzeFiles(moduleConfig(group.Module), group.Files, granularity, verbose)...)
+	}
+	return results
+}
+
+// analyzeFiles анализирует список файлов с настройками config
+func analyzeFiles(config *viper.Viper, files []string, granularity string, verbose bool) []*types.CodeAnalysisResult {
+	var results []*types.CodeAnalysisResult
+	qualityAnalyzer := analyzer.NewQualityAnalyzerWithConfig(config)
 
 	for i, file := range files {
 		if verbose {
@@ -158,17 +191,18 @@ func analyzeFiles(files []string, verbose bool) []*types.CodeAnalysisResult {
 			fmt.Printf("📝 Анализирую: %s\n", file)
 		}
 
-		result := analyzeSingleFile(file, qualityAnalyzer, verbose)
+		result := analyzeSingleFile(config, file, qualityAnalyzer, granularity, verbose)
 		if result != nil {
 			results = append(results, result)
 		}
 	}
 
+	analyzer.AssignFingerprints(results)
 	return results
 }
 
 // analyzeSingleFile анализирует один файл
-func analyzeSingleFile(file string, analyzer *analyzer.QualityAnalyzer, verbose bool) *types.CodeAnalysisResult {
+func analyzeSingleFile(config *viper.Viper, file string, qualityAnalyzer *analyzer.QualityAnalyzer, granularity string, verbose bool) *types.CodeAnalysisResult {
 	content, err := os.ReadFile(file)
 	if err != nil {
 		fmt.Printf("⚠️  Ошибка чтения %s: %v\n", file, err)
@@ -183,7 +217,9 @@ func analyzeSingleFile(file string, analyzer *analyzer.QualityAnalyzer, verbose
 	ext := strings.ToLower(filepath.Ext(file))
 	context := fmt.Sprintf("Quality analysis of %s file %s", ext, file)
 
-	result, err := analyzer.Analyze(string(content), context)
+	related := buildRelatedContext(config, file, content, verbose)
+
+	result, err := analyzer.AnalyzeWithGranularity(qualityAnalyzer, file, content, context, related, granularity, verbose)
 	if err != nil {
 		fmt.Printf("⚠️  Ошибка анализа %s: %v\n", file, err)
 		return nil
@@ -194,6 +230,7 @@ func analyzeSingleFile(file string, analyzer *analyzer.QualityAnalyzer, verbose
 	}
 
 	result.File = file
+	result.FileHash = analyzer.ContentHash(content)
 	return result
 }
 
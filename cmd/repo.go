@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"miniReviewer/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+// fileFlags флаги с путями к входным и выходным файлам: они задаются относительно
+// директории запуска
+var fileFlags = []string{"config", "output", "input", "lint", "write-commit-msg"}
+
+// repositoryRoot и pathBaseDir заполняются, если UseRepository сменил директорию
+var (
+	repositoryRoot string
+	pathBaseDir    string
+)
+
+// UseRepository переходит в корень репозитория repo (пустая строка - репозиторий
+// текущей директории), чтобы пути в результатах и отчетах были относительными
+// к корню. Флаг --path задается относительно repo, файловые флаги - относительно
+// директории запуска. Вне git репозитория без --repo ничего не меняется.
+func UseRepository(command *cobra.Command, repo string) error {
+	invocationDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("ошибка определения текущей директории: %v", err)
+	}
+
+	baseDir := invocationDir
+	if repo != "" {
+		if baseDir, err = filepath.Abs(repo); err != nil {
+			return fmt.Errorf("ошибка разбора пути %s: %v", repo, err)
+		}
+		if info, err := os.Stat(baseDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("директория репозитория %s не найдена", repo)
+		}
+	}
+
+	root, err := git.FindRoot(baseDir)
+	if err != nil {
+		if repo == "" {
+			return nil
+		}
+		// Каталог вне git: анализ файлов все равно выполняется относительно него
+		root = baseDir
+	}
+
+	if root == invocationDir {
+		return nil
+	}
+
+	for _, name := range fileFlags {
+		resolveFlagPaths(command, name, func(path string) string {
+			return absolutePath(invocationDir, path)
+		})
+	}
+	repositoryRoot, pathBaseDir = root, baseDir
+	resolveFlagPaths(command, "path", resolveAnalysisPath)
+
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("ошибка перехода в корень репозитория %s: %v", root, err)
+	}
+	return nil
+}
+
+// resolveAnalysisPath приводит путь для анализа (--path или аргумент команды)
+// к пути относительно корня репозитория
+func resolveAnalysisPath(path string) string {
+	if repositoryRoot == "" {
+		return path
+	}
+	return repositoryPath(repositoryRoot, absolutePath(pathBaseDir, path))
+}
+
+// resolveFlagPaths заменяет пути в значении строкового флага или флага-списка
+func resolveFlagPaths(command *cobra.Command, name string, resolve func(string) string) {
+	flag := command.Flags().Lookup(name)
+	if flag == nil {
+		return
+	}
+
+	if slice, ok := flag.Value.(interface {
+		GetSlice() []string
+		Replace([]string) error
+	}); ok {
+		var paths []string
+		for _, path := range slice.GetSlice() {
+			paths = append(paths, resolve(path))
+		}
+		slice.Replace(paths)
+		return
+	}
+
+	if flag.Value.Type() == "string" && flag.Value.String() != "" {
+		flag.Value.Set(resolve(flag.Value.String()))
+	}
+}
+
+// absolutePath возвращает абсолютный путь, считая относительный путь от base
+func absolutePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// repositoryPath возвращает путь относительно корня репозитория; пути вне
+// репозитория остаются абсолютными
+func repositoryPath(root, path string) string {
+	relative, err := filepath.Rel(root, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return relative
+}
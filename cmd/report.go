@@ -6,7 +6,8 @@ import (
 	"time"
 
 	"miniReviewer/internal/analyzer"
-	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/depgraph"
+	"miniReviewer/internal/lintimport"
 	"miniReviewer/internal/reporter"
 	"miniReviewer/internal/types"
 
@@ -16,7 +17,9 @@ import (
 
 // ReportCmd команда для генерации отчетов
 func ReportCmd() *cobra.Command {
-	var format, output string
+	var format, output, granularity, author string
+	var lintInputs []string
+	var overview bool
 
 	cmd := &cobra.Command{
 		Use:   "report",
@@ -26,6 +29,11 @@ func ReportCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			verbose := viper.GetBool("verbose")
 
+			if err := analyzer.ValidateGranularity(granularity); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+
 			fmt.Println("📊 Генерация отчета...")
 			fmt.Printf("Модель: %s\n", viper.GetString("ollama.default_model"))
 			fmt.Printf("Формат: %s\n", format)
@@ -68,11 +76,17 @@ func ReportCmd() *cobra.Command {
 			securityAnalyzer := analyzer.NewSecurityAnalyzer()
 			architectureAnalyzer := analyzer.NewArchitectureAnalyzer()
 
+			var deduplicator *analyzer.Deduplicator
+			if viper.GetBool("dedup.enabled") {
+				deduplicator = analyzer.NewDeduplicator()
+			}
+
 			// Определяем путь для анализа (по умолчанию текущая директория)
 			analysisPath := "."
 			if len(args) > 0 {
 				analysisPath = args[0]
 			}
+			analysisPath = resolveAnalysisPath(analysisPath)
 
 			if verbose {
 				fmt.Printf("📁 Анализирую путь: %s\n", analysisPath)
@@ -97,16 +111,19 @@ func ReportCmd() *cobra.Command {
 					os.Exit(1)
 				}
 
+				related := buildRelatedContext(viper.GetViper(), analysisPath, content, verbose)
+
 				// Создаем единый результат для файла
 				combinedResult := &types.CodeAnalysisResult{
 					File:      analysisPath,
 					Issues:    []types.Issue{},
 					Score:     100,
 					Timestamp: time.Now(),
+					FileHash:  analyzer.ContentHash(content),
 				}
 
 				// Анализируем качество кода
-				qualityResult, err := qualityAnalyzer.Analyze(string(content), fmt.Sprintf("Quality analysis of %s", analysisPath))
+				qualityResult, err := analyzer.AnalyzeWithGranularity(qualityAnalyzer, analysisPath, content, fmt.Sprintf("Quality analysis of %s", analysisPath), related, granularity, verbose)
 				if err != nil {
 					fmt.Printf("⚠️  Ошибка анализа качества: %v\n", err)
 				} else {
@@ -114,7 +131,7 @@ func ReportCmd() *cobra.Command {
 				}
 
 				// Анализируем безопасность с помощью AI
-				securityResult, err := securityAnalyzer.Analyze(string(content), fmt.Sprintf("Security analysis of %s", analysisPath))
+				securityResult, err := analyzer.AnalyzeWithGranularity(securityAnalyzer, analysisPath, content, fmt.Sprintf("Security analysis of %s", analysisPath), related, granularity, verbose)
 				if err != nil {
 					fmt.Printf("⚠️  Ошибка анализа безопасности: %v\n", err)
 				} else {
@@ -127,7 +144,7 @@ func ReportCmd() *cobra.Command {
 				}
 
 				// Анализируем архитектуру
-				architectureResult, err := architectureAnalyzer.Analyze(string(content), fmt.Sprintf("Architecture analysis of %s", analysisPath))
+				architectureResult, err := analyzer.AnalyzeWithGranularity(architectureAnalyzer, analysisPath, content, fmt.Sprintf("Architecture analysis of %s", analysisPath), related, granularity, verbose)
 				if err != nil {
 					fmt.Printf("⚠️  Ошибка анализа архитектуры: %v\n", err)
 				} else {
@@ -139,6 +156,11 @@ func ReportCmd() *cobra.Command {
 					}
 				}
 
+				// Объединяем одинаковые проблемы от разных анализаторов
+				if deduplicator != nil {
+					deduplicator.DeduplicateResult(combinedResult)
+				}
+
 				// Рассчитываем общую оценку
 				combinedResult.Score = 100 - len(combinedResult.Issues)*10
 				if combinedResult.Score < 0 {
@@ -153,90 +175,108 @@ func ReportCmd() *cobra.Command {
 					fmt.Println("📁 Сканирую директорию на поддерживаемые файлы...")
 				}
 
-				ignorePatterns := viper.GetStringSlice("analysis.ignore_patterns")
-				scanner := filesystem.NewScanner(ignorePatterns, 0)
-
-				files, err := scanner.FindSupportedFiles(analysisPath)
+				groups, skipped, err := findModuleFiles(analysisPath, nil, verbose)
 				if err != nil {
 					fmt.Printf("❌ Ошибка поиска файлов: %v\n", err)
 					os.Exit(1)
 				}
+				printSkippedFiles(skipped, verbose)
+				reportGen.SetSkipped(skipped)
+				files := moduleFileList(groups)
 
 				if verbose {
 					fmt.Printf("📋 Найдено файлов для анализа: %d\n", len(files))
 				}
 
-				for i, file := range files {
-					if verbose {
-						fmt.Printf("📝 [%d/%d] Анализирую: %s\n", i+1, len(files), file)
-					}
+				analyzed := 0
+				for _, group := range groups {
+					// Анализаторы создаются с настройками модуля
+					config := moduleConfig(group.Module)
+					qualityAnalyzer = analyzer.NewQualityAnalyzerWithConfig(config)
+					securityAnalyzer = analyzer.NewSecurityAnalyzerWithConfig(config)
+					architectureAnalyzer = analyzer.NewArchitectureAnalyzerWithConfig(config)
 
-					content, err := os.ReadFile(file)
-					if err != nil {
+					for _, file := range group.Files {
+						analyzed++
 						if verbose {
-							fmt.Printf("   ⚠️  Ошибка чтения: %v\n", err)
+							fmt.Printf("📝 [%d/%d] Анализирую: %s\n", analyzed, len(files), file)
 						}
-						continue
-					}
-
-					// Создаем единый результат для файла
-					combinedResult := &types.CodeAnalysisResult{
-						File:      file,
-						Issues:    []types.Issue{},
-						Score:     100,
-						Timestamp: time.Now(),
-					}
 
-					// Анализируем качество кода
-					qualityResult, err := qualityAnalyzer.Analyze(string(content), fmt.Sprintf("Quality analysis of %s", file))
-					if err != nil {
-						if verbose {
-							fmt.Printf("   ⚠️  Ошибка анализа качества: %v\n", err)
+						content, err := os.ReadFile(file)
+						if err != nil {
+							if verbose {
+								fmt.Printf("   ⚠️  Ошибка чтения: %v\n", err)
+							}
+							continue
 						}
-						continue
-					}
 
-					combinedResult.Issues = append(combinedResult.Issues, qualityResult.Issues...)
+						related := buildRelatedContext(config, file, content, verbose)
 
-					// Анализируем безопасность с помощью AI
-					securityResult, err := securityAnalyzer.Analyze(string(content), fmt.Sprintf("Security analysis of %s", file))
-					if err != nil {
-						if verbose {
-							fmt.Printf("   ⚠️  Ошибка анализа безопасности: %v\n", err)
+						// Создаем единый результат для файла
+						combinedResult := &types.CodeAnalysisResult{
+							File:      file,
+							Issues:    []types.Issue{},
+							Score:     100,
+							Timestamp: time.Now(),
+							FileHash:  analyzer.ContentHash(content),
 						}
-						continue
-					} else {
-						// Фильтруем только проблемы безопасности
-						for _, issue := range securityResult.Issues {
-							if issue.Type == "security" {
-								combinedResult.Issues = append(combinedResult.Issues, issue)
+
+						// Анализируем качество кода
+						qualityResult, err := analyzer.AnalyzeWithGranularity(qualityAnalyzer, file, content, fmt.Sprintf("Quality analysis of %s", file), related, granularity, verbose)
+						if err != nil {
+							if verbose {
+								fmt.Printf("   ⚠️  Ошибка анализа качества: %v\n", err)
 							}
+							continue
 						}
-					}
 
-					// Анализируем архитектуру
-					architectureResult, err := architectureAnalyzer.Analyze(string(content), fmt.Sprintf("Architecture analysis of %s", file))
-					if err != nil {
-						if verbose {
-							fmt.Printf("   ⚠️  Ошибка анализа архитектуры: %v\n", err)
+						combinedResult.Issues = append(combinedResult.Issues, qualityResult.Issues...)
+
+						// Анализируем безопасность с помощью AI
+						securityResult, err := analyzer.AnalyzeWithGranularity(securityAnalyzer, file, content, fmt.Sprintf("Security analysis of %s", file), related, granularity, verbose)
+						if err != nil {
+							if verbose {
+								fmt.Printf("   ⚠️  Ошибка анализа безопасности: %v\n", err)
+							}
+							continue
+						} else {
+							// Фильтруем только проблемы безопасности
+							for _, issue := range securityResult.Issues {
+								if issue.Type == "security" {
+									combinedResult.Issues = append(combinedResult.Issues, issue)
+								}
+							}
 						}
-						continue
-					} else {
-						// Фильтруем только архитектурные проблемы
-						for _, issue := range architectureResult.Issues {
-							if issue.Type == "architecture" {
-								combinedResult.Issues = append(combinedResult.Issues, issue)
+
+						// Анализируем архитектуру
+						architectureResult, err := analyzer.AnalyzeWithGranularity(architectureAnalyzer, file, content, fmt.Sprintf("Architecture analysis of %s", file), related, granularity, verbose)
+						if err != nil {
+							if verbose {
+								fmt.Printf("   ⚠️  Ошибка анализа архитектуры: %v\n", err)
+							}
+							continue
+						} else {
+							// Фильтруем только архитектурные проблемы
+							for _, issue := range architectureResult.Issues {
+								if issue.Type == "architecture" {
+									combinedResult.Issues = append(combinedResult.Issues, issue)
+								}
 							}
 						}
-					}
 
-					// Рассчитываем общую оценку
-					combinedResult.Score = 100 - len(combinedResult.Issues)*10
-					if combinedResult.Score < 0 {
-						combinedResult.Score = 0
-					}
+						// Объединяем одинаковые проблемы от разных анализаторов
+						if deduplicator != nil {
+							deduplicator.DeduplicateResult(combinedResult)
+						}
 
-					results = append(results, combinedResult)
+						// Рассчитываем общую оценку
+						combinedResult.Score = 100 - len(combinedResult.Issues)*10
+						if combinedResult.Score < 0 {
+							combinedResult.Score = 0
+						}
+
+						results = append(results, combinedResult)
+					}
 				}
 			}
 
@@ -245,6 +285,37 @@ func ReportCmd() *cobra.Command {
 				fmt.Println("🧠 Генерирую отчет...")
 			}
 
+			// Добавляем замечания внешних линтеров
+			if len(lintInputs) > 0 {
+				lintResults, err := loadLintResults(lintInputs, lintimport.FormatAuto, "", viper.GetBool("lint.ai_triage"), verbose)
+				if err != nil {
+					fmt.Printf("❌ %v\n", err)
+					os.Exit(1)
+				}
+				results = mergeLintResults(results, lintResults, deduplicator)
+			}
+
+			// Определяем авторов строк с проблемами
+			if attributor := newAttributor(verbose); attributor != nil {
+				attributor.AttributeResults(results, "")
+				if author != "" {
+					analyzer.FilterResultsByAuthor(results, author)
+				}
+			} else if author != "" {
+				fmt.Printf("❌ %v\n", validateAuthorFilter(author, nil))
+				os.Exit(1)
+			}
+
+			analyzer.AssignFingerprints(results)
+
+			if fileInfo.IsDir() {
+				reportGen.SetModules(workspaceModules)
+			}
+
+			if overview && fileInfo.IsDir() {
+				addModuleOverview(reportGen, analysisPath, verbose)
+			}
+
 			// Генерируем отчет
 			report, err := reportGen.GenerateReport(results, format)
 			if err != nil {
@@ -279,6 +350,26 @@ func ReportCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&format, "format", "html", "формат отчета (html, json, markdown)")
 	cmd.Flags().StringVarP(&output, "output", "o", "report.html", "файл для вывода результата")
+	cmd.Flags().StringVar(&granularity, "granularity", analyzer.GranularityFile, "гранулярность анализа (file, function)")
+	cmd.Flags().StringVar(&author, "author", "", "оставить в отчете только проблемы в строках автора (имя или email, по git blame)")
+	cmd.Flags().StringSliceVar(&lintInputs, "lint", nil, "добавить в отчет замечания линтеров из файла (go vet, staticcheck, eslint, ruff, checkstyle)")
+	cmd.Flags().BoolVar(&overview, "overview", false, "добавить в отчет иерархический обзор модулей (описания файлов и пакетов)")
 
 	return cmd
 }
+
+// addModuleOverview строит обзор модулей каталога и добавляет его в отчет
+func addModuleOverview(reportGen *reporter.Reporter, analysisPath string, verbose bool) {
+	var graph *depgraph.Graph
+	if root, _ := findGoModule(analysisPath); root != "" {
+		graph, _ = depgraph.Build(analysisPath)
+	}
+
+	moduleOverview, err := buildModuleOverview(viper.GetViper(), analysisPath, graph, verbose)
+	if err != nil {
+		fmt.Printf("⚠️  Не удалось построить обзор модулей: %v\n", err)
+		return
+	}
+
+	reportGen.SetOverview(moduleOverview)
+}
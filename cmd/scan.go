@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// newScanner создает сканер файловой системы с ограничением размера файла
+// analysis.max_file_size из config
+func newScanner(config *viper.Viper, ignorePatterns []string) *filesystem.Scanner {
+	return filesystem.NewScanner(ignorePatterns, maxFileSize(config))
+}
+
+// maxFileSize возвращает ограничение размера анализируемого файла в байтах; 0 - без ограничения
+func maxFileSize(config *viper.Viper) int64 {
+	size, err := filesystem.ParseSize(config.GetString("analysis.max_file_size"))
+	if err != nil {
+		fmt.Printf("❌ analysis.max_file_size: %v\n", err)
+		os.Exit(1)
+	}
+	return size
+}
+
+// printSkippedFile выводит причину пропуска файла
+func printSkippedFile(skipped types.SkippedFile) {
+	if skipped.Detail != "" {
+		fmt.Printf("⏭️  Пропускаю %s: %s (%s)\n", skipped.File, filesystem.SkipReasonName(skipped.Reason), skipped.Detail)
+		return
+	}
+	fmt.Printf("⏭️  Пропускаю %s: %s\n", skipped.File, filesystem.SkipReasonName(skipped.Reason))
+}
+
+// printSkippedFiles выводит пропущенные сканером файлы в подробном режиме
+func printSkippedFiles(skipped []types.SkippedFile, verbose bool) {
+	if !verbose || len(skipped) == 0 {
+		return
+	}
+	fmt.Printf("⏭️  Пропущено файлов: %d\n", len(skipped))
+	for _, file := range skipped {
+		printSkippedFile(file)
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"miniReviewer/internal/analyzer"
-	"miniReviewer/internal/filesystem"
 	"miniReviewer/internal/types"
 
 	"github.com/spf13/cobra"
@@ -18,7 +18,7 @@ import (
 // SecurityCmd команда для анализа безопасности
 func SecurityCmd() *cobra.Command {
 	var checkDeps, scanCode bool
-	var output, path string
+	var output, path, granularity, author string
 
 	cmd := &cobra.Command{
 		Use:   "security",
@@ -27,7 +27,7 @@ func SecurityCmd() *cobra.Command {
 Проверяет зависимости, сканирует код и предлагает исправления.
 Может анализировать как отдельные файлы, так и целые директории.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runSecurityAnalysis(checkDeps, scanCode, output, path)
+			runSecurityAnalysis(checkDeps, scanCode, output, path, granularity, author)
 		},
 	}
 
@@ -35,26 +35,49 @@ func SecurityCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&checkDeps, "check-dependencies", true, "проверка зависимостей на уязвимости")
 	cmd.Flags().BoolVar(&scanCode, "scan-code", true, "сканирование кода на проблемы безопасности")
 	cmd.Flags().StringVarP(&output, "output", "o", "", "файл для вывода результата")
+	cmd.Flags().StringVar(&granularity, "granularity", analyzer.GranularityFile, "гранулярность анализа (file, function)")
+	cmd.Flags().StringVar(&author, "author", "", "оставить только проблемы в строках автора (имя или email, по git blame)")
 
 	return cmd
 }
 
 // runSecurityAnalysis выполняет анализ безопасности
-func runSecurityAnalysis(checkDeps, scanCode bool, output, path string) {
+func runSecurityAnalysis(checkDeps, scanCode bool, output, path, granularity, author string) {
 	verbose := viper.GetBool("verbose")
 
+	if err := analyzer.ValidateGranularity(granularity); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	attributor := newAttributor(verbose)
+	if err := validateAuthorFilter(author, attributor); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
 	printSecurityHeader(checkDeps, scanCode, verbose)
 
 	if scanCode {
 		// Выполняем сканирование кода
-		securityIssues := scanCodeForSecurityIssues(path, verbose)
+		securityIssues, fileHashes := scanCodeForSecurityIssues(path, granularity, verbose)
+
+		// Определяем авторов строк с проблемами
+		if attributor != nil {
+			attributor.AttributeIssues(securityIssues, "", "")
+			if author != "" {
+				securityIssues = analyzer.FilterIssuesByAuthor(securityIssues, author)
+				fmt.Printf("👤 Фильтр по автору: %s\n", author)
+			}
+		}
 
 		// Выводим результаты
 		printSecurityResults(securityIssues, verbose)
+		analyzer.PrintAuthorStatistics([]*types.CodeAnalysisResult{{Issues: securityIssues}})
 
 		// Сохраняем результаты если указан файл
 		if output != "" {
-			saveSecurityResults(securityIssues, output, verbose)
+			saveSecurityResults(securityIssues, fileHashes, output, verbose)
 		}
 	}
 
@@ -83,8 +106,9 @@ func printSecuritySettings() {
 	fmt.Printf("  - Проверка разрешений: %t\n", viper.GetBool("security.check_permissions"))
 }
 
-// scanCodeForSecurityIssues сканирует код на проблемы безопасности
-func scanCodeForSecurityIssues(path string, verbose bool) []types.Issue {
+// scanCodeForSecurityIssues сканирует код на проблемы безопасности.
+// Вместе с проблемами возвращает хеши содержимого проанализированных файлов.
+func scanCodeForSecurityIssues(path, granularity string, verbose bool) ([]types.Issue, map[string]string) {
 	fmt.Println("🔍 Сканирую код на проблемы безопасности...")
 
 	// Определяем путь для анализа
@@ -94,19 +118,24 @@ func scanCodeForSecurityIssues(path string, verbose bool) []types.Issue {
 		fmt.Println("📁 Поиск поддерживаемых файлов для сканирования...")
 	}
 
-	// Получаем список файлов для анализа
-	files, err := getSecurityFilesForAnalysis(analysisPath, verbose)
+	// Получаем список файлов для анализа по модулям
+	groups, err := getSecurityFilesForAnalysis(analysisPath, verbose)
 	if err != nil {
 		fmt.Printf("❌ Ошибка поиска файлов: %v\n", err)
 		os.Exit(1)
 	}
 
 	if verbose {
-		fmt.Printf("📋 Найдено файлов для сканирования: %d\n", len(files))
+		fmt.Printf("📋 Найдено файлов для сканирования: %d\n", len(moduleFileList(groups)))
 	}
 
-	// Анализируем файлы на проблемы безопасности
-	return analyzeFilesForSecurity(files, verbose)
+	// Анализируем файлы на проблемы безопасности: каждый модуль со своими настройками
+	var securityIssues []types.Issue
+	fileHashes := make(map[string]string)
+	for _, group := range groups {
+		securityIssues = append(securityIssues, analyzeFilesForSecurity(moduleConfig(group.Module), group.Files, granularity, fileHashes, verbose)...)
+	}
+	return securityIssues, fileHashes
 }
 
 // getSecurityAnalysisPath возвращает путь для анализа безопасности
@@ -117,18 +146,17 @@ func getSecurityAnalysisPath(path string) string {
 	return "."
 }
 
-// getSecurityFilesForAnalysis получает список файлов для анализа безопасности
-func getSecurityFilesForAnalysis(analysisPath string, verbose bool) ([]string, error) {
-	ignorePatterns := viper.GetStringSlice("analysis.ignore_patterns")
-	scanner := filesystem.NewScanner(ignorePatterns, 0)
-
+// getSecurityFilesForAnalysis получает список файлов для анализа безопасности с разбивкой по модулям
+func getSecurityFilesForAnalysis(analysisPath string, verbose bool) ([]moduleFiles, error) {
 	// Проверяем, является ли путь файлом
 	if fileInfo, statErr := os.Stat(analysisPath); statErr == nil && !fileInfo.IsDir() {
-		return getSingleSecurityFileForAnalysis(analysisPath)
+		return getSingleModuleFile(analysisPath, getSingleSecurityFileForAnalysis)
 	}
 
-	// Это директория - ищем поддерживаемые файлы
-	return scanner.FindSupportedFiles(analysisPath)
+	// Это директория - ищем поддерживаемые файлы в каждом модуле
+	groups, skipped, err := findModuleFiles(analysisPath, nil, verbose)
+	printSkippedFiles(skipped, verbose)
+	return groups, err
 }
 
 // getSingleSecurityFileForAnalysis проверяет и возвращает один файл для анализа безопасности
@@ -145,17 +173,17 @@ func getSingleSecurityFileForAnalysis(filePath string) ([]string, error) {
 	return nil, fmt.Errorf("файл %s не поддерживается. Поддерживаемые расширения: %v", filePath, supportedExtensions)
 }
 
-// analyzeFilesForSecurity анализирует файлы на проблемы безопасности
-func analyzeFilesForSecurity(files []string, verbose bool) []types.Issue {
+// analyzeFilesForSecurity анализирует файлы на проблемы безопасности с настройками config
+func analyzeFilesForSecurity(config *viper.Viper, files []string, granularity string, fileHashes map[string]string, verbose bool) []types.Issue {
 	var securityIssues []types.Issue
-	securityAnalyzer := analyzer.NewSecurityAnalyzer()
+	securityAnalyzer := analyzer.NewSecurityAnalyzerWithConfig(config)
 
 	for i, file := range files {
 		if verbose {
 			fmt.Printf("🔍 [%d/%d] Сканирую: %s\n", i+1, len(files), file)
 		}
 
-		fileIssues := analyzeSingleFileForSecurity(file, securityAnalyzer, verbose)
+		fileIssues := analyzeSingleFileForSecurity(config, file, securityAnalyzer, granularity, fileHashes, verbose)
 		securityIssues = append(securityIssues, fileIssues...)
 	}
 
@@ -163,7 +191,7 @@ func analyzeFilesForSecurity(files []string, verbose bool) []types.Issue {
 }
 
 // analyzeSingleFileForSecurity анализирует один файл на проблемы безопасности
-func analyzeSingleFileForSecurity(file string, analyzer *analyzer.SecurityAnalyzer, verbose bool) []types.Issue {
+func analyzeSingleFileForSecurity(config *viper.Viper, file string, securityAnalyzer *analyzer.SecurityAnalyzer, granularity string, fileHashes map[string]string, verbose bool) []types.Issue {
 	content, err := os.ReadFile(file)
 	if err != nil {
 		if verbose {
@@ -177,7 +205,9 @@ func analyzeSingleFileForSecurity(file string, analyzer *analyzer.SecurityAnalyz
 	}
 
 	// Анализируем код на проблемы безопасности с помощью AI
-	aiResult, err := analyzer.Analyze(string(content), fmt.Sprintf("Security analysis of %s file", filepath.Ext(file)))
+	related := buildRelatedContext(config, file, content, verbose)
+	context := fmt.Sprintf("Security analysis of %s file", filepath.Ext(file))
+	aiResult, err := analyzer.AnalyzeWithGranularity(securityAnalyzer, file, content, context, related, granularity, verbose)
 	if err != nil {
 		if verbose {
 			fmt.Printf("   ⚠️  Ошибка AI-анализа: %v\n", err)
@@ -185,16 +215,20 @@ func analyzeSingleFileForSecurity(file string, analyzer *analyzer.SecurityAnalyz
 		return []types.Issue{}
 	}
 
+	fileHashes[file] = analyzer.ContentHash(content)
+
 	// Фильтруем только проблемы безопасности из AI-анализа
 	var securityIssues []types.Issue
 	for _, aiIssue := range aiResult.Issues {
-		if isSecurityIssue(aiIssue.Type) {
+		if isSecurityIssue(&aiIssue) {
 			// Добавляем информацию о файле
 			aiIssue.File = file
 			securityIssues = append(securityIssues, aiIssue)
 		}
 	}
 
+	analyzer.AssignIssueFingerprints(securityIssues, file)
+
 	if verbose && len(aiResult.Issues) > 0 {
 		fmt.Printf("   ⚠️  Найдено проблем: %d\n", len(aiResult.Issues))
 	}
@@ -202,17 +236,10 @@ func analyzeSingleFileForSecurity(file string, analyzer *analyzer.SecurityAnalyz
 	return securityIssues
 }
 
-// isSecurityIssue проверяет, является ли проблема проблемой безопасности
-func isSecurityIssue(issueType string) bool {
-	securityTypes := []string{"security", "vulnerability", "injection", "xss", "sqli", "authentication", "authorization"}
-
-	for _, securityType := range securityTypes {
-		if issueType == securityType {
-			return true
-		}
-	}
-
-	return false
+// isSecurityIssue проверяет, является ли проблема проблемой безопасности.
+// Подтипы (sql_injection, command_injection и т.д.) нормализуются в таксономию CWE/OWASP.
+func isSecurityIssue(issue *types.Issue) bool {
+	return analyzer.ClassifySecurityIssue(issue)
 }
 
 // printSecurityResults выводит результаты анализа безопасности
@@ -234,18 +261,16 @@ func printSecurityResults(securityIssues []types.Issue, verbose bool) {
 
 // printSecurityStatistics выводит статистику по безопасности
 func printSecurityStatistics(securityIssues []types.Issue) {
-	fmt.Printf("📈 Статистика по типам проблем:\n")
-	issueTypes := make(map[string]int)
+	fmt.Printf("📈 Статистика по CWE:\n")
 	severityCounts := make(map[string]int)
 
 	for _, issue := range securityIssues {
-		issueTypes[issue.Type]++
 		severityCounts[issue.Severity]++
 	}
 
-	fmt.Printf("  По типам:\n")
-	for issueType, count := range issueTypes {
-		fmt.Printf("    - %s: %d\n", issueType, count)
+	fmt.Printf("  По CWE:\n")
+	for _, stat := range analyzer.GroupByCWE(securityIssues) {
+		fmt.Printf("    - %s: %d\n", getCWELabel(stat.CWE), stat.Count)
 	}
 
 	fmt.Printf("  По важности:\n")
@@ -259,41 +284,32 @@ func printSecurityStatistics(securityIssues []types.Issue) {
 func printSecurityIssues(securityIssues []types.Issue, verbose bool) {
 	fmt.Printf("\n🔍 Найденные проблемы безопасности:\n")
 
-	// Группируем проблемы по типам
-	issuesByType := make(map[string][]types.Issue)
+	// Группируем проблемы по CWE
+	issuesByCWE := make(map[string][]types.Issue)
 	for _, issue := range securityIssues {
-		issuesByType[issue.Type] = append(issuesByType[issue.Type], issue)
+		issuesByCWE[issue.CWE] = append(issuesByCWE[issue.CWE], issue)
 	}
 
-	// Определяем порядок приоритета типов безопасности
-	typePriority := []string{"security", "vulnerability", "injection", "xss", "sqli", "authentication", "authorization"}
-
-	for _, issueType := range typePriority {
-		if issues, exists := issuesByType[issueType]; exists {
-			printSecurityIssueTypeGroup(issueType, issues, verbose)
-		}
+	// Группы выводим в порядке убывания количества проблем
+	for _, stat := range analyzer.GroupByCWE(securityIssues) {
+		printSecurityCWEGroup(stat, issuesByCWE[stat.CWE], verbose)
 	}
 }
 
-// printSecurityIssueTypeGroup выводит группу проблем безопасности одного типа
-func printSecurityIssueTypeGroup(issueType string, issues []types.Issue, verbose bool) {
-	// Эмодзи для разных типов проблем безопасности
-	typeEmoji := map[string]string{
-		"security":       "🔒",
-		"vulnerability":  "💥",
-		"injection":      "💉",
-		"xss":            "🌐",
-		"sqli":           "🗄️",
-		"authentication": "🔐",
-		"authorization":  "🚪",
+// printSecurityCWEGroup выводит группу проблем безопасности одной CWE
+func printSecurityCWEGroup(stat analyzer.CWEStatistic, issues []types.Issue, verbose bool) {
+	header := getCWELabel(stat.CWE)
+	if stat.OWASP != "" {
+		header = fmt.Sprintf("%s [OWASP %s]", header, getOWASPLabel(stat.OWASP))
 	}
 
-	emoji := typeEmoji[issueType]
-	if emoji == "" {
-		emoji = "⚠️"
-	}
+	fmt.Printf("\n🔒 %s (%d проблем):\n", header, len(issues))
 
-	fmt.Printf("\n%s %s (%d проблем):\n", emoji, strings.ToUpper(issueType), len(issues))
+	// При пофункциональном анализе группируем проблемы по файлам и функциям
+	if analyzer.HasFunctionInfo(issues) {
+		printSecurityIssuesByFunction(issues, verbose)
+		return
+	}
 
 	for i, issue := range issues {
 		printSecurityIssue(issue, verbose)
@@ -305,6 +321,29 @@ func printSecurityIssueTypeGroup(issueType string, issues []types.Issue, verbose
 	}
 }
 
+// printSecurityIssuesByFunction выводит проблемы безопасности, сгруппированные по функциям
+func printSecurityIssuesByFunction(issues []types.Issue, verbose bool) {
+	var order []string
+	groups := make(map[string][]types.Issue)
+	for _, issue := range issues {
+		key := issue.File
+		if issue.Function != "" {
+			key = fmt.Sprintf("%s → %s", issue.File, issue.Function)
+		}
+		if _, exists := groups[key]; !exists {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], issue)
+	}
+
+	for _, key := range order {
+		fmt.Printf("\n  🔧 %s (%d):\n", key, len(groups[key]))
+		for _, issue := range groups[key] {
+			printSecurityIssue(issue, verbose)
+		}
+	}
+}
+
 // printSecurityIssue выводит одну проблему безопасности
 func printSecurityIssue(issue types.Issue, verbose bool) {
 	// Эмодзи для важности
@@ -331,6 +370,14 @@ func printSecurityIssue(issue types.Issue, verbose bool) {
 		fmt.Printf("     📁 Файл: %s\n", issue.File)
 	}
 
+	if issue.Function != "" {
+		fmt.Printf("     🔧 Функция: %s\n", issue.Function)
+	}
+
+	if issue.CWE != "" {
+		fmt.Printf("     🏷️  %s, OWASP %s\n", getCWELabel(issue.CWE), getOWASPLabel(issue.OWASP))
+	}
+
 	if issue.Suggestion != "" {
 		fmt.Printf("     💡 Решение: %s\n", issue.Suggestion)
 	}
@@ -345,15 +392,15 @@ func printSecuritySummary(securityIssues []types.Issue, verbose bool) {
 	fmt.Printf("\n📈 Сводная статистика безопасности:\n")
 
 	severityCounts := make(map[string]int)
-	typeCounts := make(map[string]int)
+	owaspCounts := make(map[string]int)
 
 	for _, issue := range securityIssues {
 		severityCounts[issue.Severity]++
-		typeCounts[issue.Type]++
+		owaspCounts[issue.OWASP]++
 	}
 
 	printSecuritySeverityStatistics(severityCounts)
-	printSecurityTypeStatistics(typeCounts)
+	printSecurityOWASPStatistics(owaspCounts)
 }
 
 // printSecuritySeverityStatistics выводит статистику безопасности по важности
@@ -373,41 +420,90 @@ func printSecuritySeverityStatistics(severityCounts map[string]int) {
 	}
 }
 
-// printSecurityTypeStatistics выводит статистику безопасности по типам
-func printSecurityTypeStatistics(typeCounts map[string]int) {
-	fmt.Printf("  📊 По типам:\n")
-	for _, issueType := range []string{"security", "vulnerability", "injection", "xss", "sqli", "authentication", "authorization"} {
-		if count := typeCounts[issueType]; count > 0 {
-			emoji := map[string]string{
-				"security":       "🔒",
-				"vulnerability":  "💥",
-				"injection":      "💉",
-				"xss":            "🌐",
-				"sqli":           "🗄️",
-				"authentication": "🔐",
-				"authorization":  "🚪",
-			}[issueType]
-			fmt.Printf("    %s %s: %d\n", emoji, strings.ToUpper(issueType), count)
+// printSecurityOWASPStatistics выводит статистику безопасности по категориям OWASP Top 10
+func printSecurityOWASPStatistics(owaspCounts map[string]int) {
+	fmt.Printf("  📊 По OWASP Top 10:\n")
+	categories := make([]string, 0, len(analyzer.OWASPCategories))
+	for category := range analyzer.OWASPCategories {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		if count := owaspCounts[category]; count > 0 {
+			fmt.Printf("    - %s %s: %d\n", category, analyzer.OWASPCategories[category], count)
 		}
 	}
+	if count := owaspCounts[""]; count > 0 {
+		fmt.Printf("    - %s: %d\n", getOWASPLabel(""), count)
+	}
+}
+
+// getCWELabel возвращает подпись CWE для вывода в консоль
+func getCWELabel(cwe string) string {
+	if cwe == "" {
+		return "Без CWE"
+	}
+	if name := analyzer.CWEName(cwe); name != "" {
+		return fmt.Sprintf("%s %s", cwe, name)
+	}
+	return cwe
+}
+
+// getOWASPLabel возвращает подпись категории OWASP для вывода в консоль
+func getOWASPLabel(owasp string) string {
+	if owasp == "" {
+		return "без категории"
+	}
+	return owasp
 }
 
 // saveSecurityResults сохраняет результаты анализа безопасности в файл
-func saveSecurityResults(securityIssues []types.Issue, output string, verbose bool) {
+func saveSecurityResults(securityIssues []types.Issue, fileHashes map[string]string, output string, verbose bool) {
 	if verbose {
 		fmt.Printf("💾 Сохраняю результаты в файл: %s\n", output)
 	}
 
-	// Создаем результат для сохранения
-	result := &types.CodeAnalysisResult{
-		Issues:    securityIssues,
-		Score:     100 - len(securityIssues)*10, // Оценка на основе количества проблем
-		Timestamp: time.Now(),
-	}
-
-	if err := saveResultsToFile([]*types.CodeAnalysisResult{result}, output); err != nil {
+	if err := saveResultsToFile(buildSecurityFileResults(securityIssues, fileHashes), output); err != nil {
 		fmt.Printf("❌ Ошибка сохранения: %v\n", err)
 	} else {
 		fmt.Printf("\n💾 Результаты сохранены в: %s\n", output)
 	}
 }
+
+// buildSecurityFileResults группирует проблемы безопасности в результаты по файлам
+func buildSecurityFileResults(securityIssues []types.Issue, fileHashes map[string]string) []*types.CodeAnalysisResult {
+	issuesByFile := make(map[string][]types.Issue)
+	for _, issue := range securityIssues {
+		issuesByFile[issue.File] = append(issuesByFile[issue.File], issue)
+	}
+
+	files := make([]string, 0, len(fileHashes))
+	for file := range fileHashes {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var results []*types.CodeAnalysisResult
+	for _, file := range files {
+		issues := issuesByFile[file]
+		if issues == nil {
+			issues = []types.Issue{}
+		}
+
+		score := 100 - len(issues)*10 // Оценка на основе количества проблем
+		if score < 0 {
+			score = 0
+		}
+
+		results = append(results, &types.CodeAnalysisResult{
+			File:      file,
+			Issues:    issues,
+			Score:     score,
+			Timestamp: time.Now(),
+			FileHash:  fileHashes[file],
+		})
+	}
+
+	return results
+}
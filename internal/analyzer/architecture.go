@@ -3,10 +3,13 @@ package analyzer
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"miniReviewer/internal/ollama"
 	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
 )
 
 // ArchitectureAnalyzer анализатор архитектуры кода
@@ -16,25 +19,49 @@ type ArchitectureAnalyzer struct {
 
 // NewArchitectureAnalyzer создает новый анализатор архитектуры
 func NewArchitectureAnalyzer() *ArchitectureAnalyzer {
+	return NewArchitectureAnalyzerWithConfig(viper.GetViper())
+}
+
+// NewArchitectureAnalyzerWithConfig создает анализатор архитектуры с настройками из config
+func NewArchitectureAnalyzerWithConfig(config *viper.Viper) *ArchitectureAnalyzer {
 	return &ArchitectureAnalyzer{
-		ollamaClient: ollama.NewClient(),
+		ollamaClient: ollama.NewClientWithConfig(config),
 	}
 }
 
 // Analyze анализирует архитектуру кода
 func (a *ArchitectureAnalyzer) Analyze(code string, context string) (*types.CodeAnalysisResult, error) {
-	prompt := a.buildPrompt(code, context)
+	return a.AnalyzeWithRelated(code, context, "")
+}
+
+// AnalyzeWithRelated анализирует код с учетом объявлений из других файлов
+func (a *ArchitectureAnalyzer) AnalyzeWithRelated(code, context, related string) (*types.CodeAnalysisResult, error) {
+	prompt := a.buildPrompt(code, context, related, "")
+	return a.analyzeWithAI(prompt)
+}
+
+// AnalyzeProject анализирует структуру проекта с учетом графа зависимостей,
+// вычисленного статически
+func (a *ArchitectureAnalyzer) AnalyzeProject(structure, dependencies string) (*types.CodeAnalysisResult, error) {
+	prompt := a.buildPrompt(structure, "Project architecture analysis", "", dependencies)
+	return a.analyzeWithAI(prompt)
+}
+
+// AnalyzeOverview анализирует архитектуру по описаниям пакетов, полученным
+// иерархическим сведением описаний файлов, и графу зависимостей
+func (a *ArchitectureAnalyzer) AnalyzeOverview(overview, dependencies string) (*types.CodeAnalysisResult, error) {
+	prompt := a.buildPrompt(overview, "Project architecture review based on package summaries", "", dependencies)
 	return a.analyzeWithAI(prompt)
 }
 
 // buildPrompt строит промпт для анализа архитектуры
-func (a *ArchitectureAnalyzer) buildPrompt(code string, context string) string {
+func (a *ArchitectureAnalyzer) buildPrompt(code, context, related, dependencies string) string {
 	language := detectLanguage(context)
 
 	return fmt.Sprintf(`Ты - эксперт по архитектуре кода на языке %s. Проанализируй следующий код с точки зрения архитектуры:
 
 КОНТЕКСТ: %s
-
+%s%s
 КОД:
 %s
 
@@ -87,7 +114,19 @@ func (a *ArchitectureAnalyzer) buildPrompt(code string, context string) string {
       "reasoning": "Как это влияет на архитектуру"
     }
   ]
-}`, language, context, code, language)
+}`, language, context, buildRelatedSection(related), buildDependencySection(dependencies), code)
+}
+
+// buildDependencySection формирует секцию промпта с графом импортов
+func buildDependencySection(dependencies string) string {
+	if strings.TrimSpace(dependencies) == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+ГРАФ ИМПОРТОВ ПАКЕТОВ (вычислен статически, это точные факты - опирайся на них при оценке слоев, связности и циклов):
+%s
+`, dependencies)
 }
 
 // analyzeWithAI выполняет AI-анализ
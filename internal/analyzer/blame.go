@@ -0,0 +1,193 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/types"
+)
+
+// uncommittedAuthor имя, которым git blame помечает незакоммиченные строки
+const uncommittedAuthor = "Not Committed Yet"
+
+// Attributor определяет авторов строк с проблемами через git blame.
+// Результат blame кэшируется на файл и ревизию.
+type Attributor struct {
+	gitClient *git.Client
+	cache     map[string]map[int]git.BlameLine
+}
+
+// AuthorStatistic количество проблем в строках одного автора
+type AuthorStatistic struct {
+	Author     string         `json:"author"`
+	Email      string         `json:"email,omitempty"`
+	Issues     int            `json:"issues"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// NewAttributor создает определитель авторов
+func NewAttributor(gitClient *git.Client) *Attributor {
+	return &Attributor{
+		gitClient: gitClient,
+		cache:     make(map[string]map[int]git.BlameLine),
+	}
+}
+
+// AttributeResults добавляет автора к проблемам результатов. revision пустой
+// для рабочей копии. Возвращает количество проблем, для которых найден автор.
+func (a *Attributor) AttributeResults(results []*types.CodeAnalysisResult, revision string) int {
+	attributed := 0
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		attributed += a.AttributeIssues(result.Issues, result.File, revision)
+	}
+	return attributed
+}
+
+// AttributeIssues добавляет автора к проблемам одного файла (или проблемам со своим File)
+func (a *Attributor) AttributeIssues(issues []types.Issue, defaultFile, revision string) int {
+	attributed := 0
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Line <= 0 {
+			continue
+		}
+
+		file := issue.File
+		if file == "" {
+			file = defaultFile
+		}
+
+		blame := a.blame(revision, file)
+		line, ok := blame[issue.Line]
+		if !ok {
+			continue
+		}
+
+		issue.Blame = &types.BlameInfo{
+			Author: line.Author,
+			Email:  line.Email,
+			Commit: line.Commit,
+			Date:   line.Date,
+		}
+		attributed++
+	}
+	return attributed
+}
+
+// blame возвращает авторов строк файла; файлы вне репозитория дают пустой результат
+func (a *Attributor) blame(revision, file string) map[int]git.BlameLine {
+	key := revision + ":" + file
+	if lines, ok := a.cache[key]; ok {
+		return lines
+	}
+
+	lines, err := a.gitClient.Blame(revision, file)
+	if err != nil {
+		lines = nil
+	}
+	a.cache[key] = lines
+	return lines
+}
+
+// MatchesAuthor проверяет, написана ли строка с проблемой указанным автором
+// (подстрока имени или email без учета регистра)
+func MatchesAuthor(issue types.Issue, author string) bool {
+	if issue.Blame == nil {
+		return false
+	}
+	author = strings.ToLower(author)
+	return strings.Contains(strings.ToLower(issue.Blame.Author), author) ||
+		strings.Contains(strings.ToLower(issue.Blame.Email), author)
+}
+
+// FilterIssuesByAuthor оставляет проблемы в строках указанного автора
+func FilterIssuesByAuthor(issues []types.Issue, author string) []types.Issue {
+	filtered := []types.Issue{}
+	for _, issue := range issues {
+		if MatchesAuthor(issue, author) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// FilterResultsByAuthor оставляет в результатах проблемы в строках указанного автора
+func FilterResultsByAuthor(results []*types.CodeAnalysisResult, author string) {
+	for _, result := range results {
+		if result != nil {
+			result.Issues = FilterIssuesByAuthor(result.Issues, author)
+		}
+	}
+}
+
+// GroupByAuthor считает проблемы по авторам строк, в порядке убывания количества.
+// Проблемы без автора попадают в группу с пустым именем.
+func GroupByAuthor(results []*types.CodeAnalysisResult) []AuthorStatistic {
+	byAuthor := make(map[string]*AuthorStatistic)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, issue := range result.Issues {
+			author, email := "", ""
+			if issue.Blame != nil {
+				author, email = issue.Blame.Author, issue.Blame.Email
+			}
+
+			stat, exists := byAuthor[author]
+			if !exists {
+				stat = &AuthorStatistic{Author: author, Email: email, BySeverity: make(map[string]int)}
+				byAuthor[author] = stat
+			}
+			stat.Issues++
+			stat.BySeverity[issue.Severity]++
+		}
+	}
+
+	statistics := make([]AuthorStatistic, 0, len(byAuthor))
+	for _, stat := range byAuthor {
+		statistics = append(statistics, *stat)
+	}
+	sort.Slice(statistics, func(i, j int) bool {
+		if statistics[i].Issues != statistics[j].Issues {
+			return statistics[i].Issues > statistics[j].Issues
+		}
+		return statistics[i].Author < statistics[j].Author
+	})
+	return statistics
+}
+
+// AuthorLabel возвращает имя автора для вывода
+func AuthorLabel(author string) string {
+	switch author {
+	case "":
+		return "Автор не определен"
+	case uncommittedAuthor:
+		return "Незакоммиченные изменения"
+	}
+	return author
+}
+
+// PrintAuthorStatistics выводит количество проблем по авторам строк
+func PrintAuthorStatistics(results []*types.CodeAnalysisResult) {
+	statistics := GroupByAuthor(results)
+	if len(statistics) == 0 || (len(statistics) == 1 && statistics[0].Author == "") {
+		return
+	}
+
+	fmt.Printf("\n👥 Проблемы по авторам:\n")
+	for _, stat := range statistics {
+		var parts []string
+		for _, severity := range []string{"critical", "high", "medium", "low", "info"} {
+			if count := stat.BySeverity[severity]; count > 0 {
+				parts = append(parts, fmt.Sprintf("%s: %d", severity, count))
+			}
+		}
+		fmt.Printf("  👤 %s: %d (%s)\n", AuthorLabel(stat.Author), stat.Issues, strings.Join(parts, ", "))
+	}
+}
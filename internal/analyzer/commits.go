@@ -0,0 +1,264 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// maxCommitDiffSize ограничение размера diff коммита в промпте
+const maxCommitDiffSize = 8000
+
+var (
+	conventionalPattern = regexp.MustCompile(`^([a-z]+)(\([^()]+\))?(!)?: (.+)$`)
+	fixupPattern        = regexp.MustCompile(`^(fixup|squash|amend)! `)
+	wipPattern          = regexp.MustCompile(`(?i)(^|[^\p{L}])(wip|work in progress)([^\p{L}]|$)`)
+	// mergeSubjectPattern заголовки, которые git и хостинги формируют для merge-коммитов
+	mergeSubjectPattern = regexp.MustCompile(`^(Merge (branch|branches|remote-tracking branch|tag|commit|pull request|PR) |Merge .+ into .+$|Merged .+ into .+$)`)
+)
+
+// CommitAnalysisOptions пороги проверок коммитов
+type CommitAnalysisOptions struct {
+	ConventionalTypes []string // допустимые типы conventional commits; пустой список отключает проверку
+	MaxSubjectLength  int      // максимальная длина заголовка
+	MaxChangedLines   int      // порог "слишком большого" коммита по строкам
+	MaxFiles          int      // порог "слишком большого" коммита по файлам
+	MaxAreas          int      // сколько разных областей кода может затрагивать один коммит
+}
+
+// CommitAnalyzer анализатор сообщений и состава коммитов
+type CommitAnalyzer struct {
+	ollamaClient *ollama.Client
+	options      CommitAnalysisOptions
+}
+
+// NewCommitAnalyzer создает анализатор коммитов с настройками из секции commits
+func NewCommitAnalyzer() *CommitAnalyzer {
+	return &CommitAnalyzer{
+		ollamaClient: ollama.NewClient(),
+		options: CommitAnalysisOptions{
+			ConventionalTypes: viper.GetStringSlice("commits.conventional_types"),
+			MaxSubjectLength:  viper.GetInt("commits.max_subject_length"),
+			MaxChangedLines:   viper.GetInt("commits.max_changed_lines"),
+			MaxFiles:          viper.GetInt("commits.max_files"),
+			MaxAreas:          viper.GetInt("commits.max_areas"),
+		},
+	}
+}
+
+// CheckMessage проверяет формат сообщения коммита (conventional commits, длина заголовка).
+// Merge-коммиты со стандартным заголовком ("Merge branch 'x'", "Merge pull request #N")
+// не проверяются, как и в commitlint.
+func (a *CommitAnalyzer) CheckMessage(commit git.Commit) []types.Issue {
+	if IsDefaultMergeCommit(commit) {
+		return nil
+	}
+
+	var issues []types.Issue
+	subject := commit.Subject
+
+	if len(a.options.ConventionalTypes) > 0 && !IsFixupCommit(commit) {
+		match := conventionalPattern.FindStringSubmatch(subject)
+		switch {
+		case match == nil:
+			issues = append(issues, commitIssue("low",
+				"Сообщение не соответствует формату Conventional Commits",
+				fmt.Sprintf("Используйте формат \"тип(область): описание\", например \"fix(git): ...\". Допустимые типы: %s", strings.Join(a.options.ConventionalTypes, ", "))))
+		case !containsString(a.options.ConventionalTypes, match[1]):
+			issues = append(issues, commitIssue("low",
+				fmt.Sprintf("Неизвестный тип коммита \"%s\"", match[1]),
+				fmt.Sprintf("Допустимые типы: %s", strings.Join(a.options.ConventionalTypes, ", "))))
+		}
+	}
+
+	if a.options.MaxSubjectLength > 0 && utf8.RuneCountInString(subject) > a.options.MaxSubjectLength {
+		issues = append(issues, commitIssue("low",
+			fmt.Sprintf("Заголовок коммита слишком длинный: %d символов (максимум %d)", utf8.RuneCountInString(subject), a.options.MaxSubjectLength),
+			"Сократите заголовок, а подробности перенесите в тело сообщения"))
+	}
+
+	if strings.HasSuffix(subject, ".") {
+		issues = append(issues, commitIssue("info",
+			"Заголовок коммита заканчивается точкой",
+			"Уберите точку в конце заголовка"))
+	}
+
+	return issues
+}
+
+// CheckSize проверяет, не слишком ли большой коммит и не смешивает ли он несвязанные изменения
+func (a *CommitAnalyzer) CheckSize(commit git.Commit) []types.Issue {
+	var issues []types.Issue
+
+	changed := commit.ChangedLines()
+	if (a.options.MaxChangedLines > 0 && changed > a.options.MaxChangedLines) || (a.options.MaxFiles > 0 && len(commit.Files) > a.options.MaxFiles) {
+		issues = append(issues, commitIssue("medium",
+			fmt.Sprintf("Слишком большой коммит: %d файлов, %d измененных строк", len(commit.Files), changed),
+			"Разбейте изменения на несколько логически завершенных коммитов - их проще ревьюить и откатывать"))
+	}
+
+	areas := ChangedAreas(commit)
+	if a.options.MaxAreas > 0 && len(areas) > a.options.MaxAreas {
+		issues = append(issues, commitIssue("medium",
+			fmt.Sprintf("Коммит смешивает изменения в %d областях: %s", len(areas), strings.Join(areas, ", ")),
+			"Выделите независимые изменения в отдельные коммиты"))
+	}
+
+	return issues
+}
+
+// CheckLeftover проверяет WIP и fixup коммиты. beforeMerge означает, что коммит еще
+// не влит в основную ветку и будет влит вместе с текущей веткой.
+func (a *CommitAnalyzer) CheckLeftover(commit git.Commit, beforeMerge bool) []types.Issue {
+	severity := "medium"
+	suffix := ""
+	if beforeMerge {
+		severity = "high"
+		suffix = " и будет влит в основную ветку"
+	}
+
+	if IsFixupCommit(commit) {
+		return []types.Issue{commitIssue(severity,
+			fmt.Sprintf("Fixup/squash коммит не объединен с исходным%s", suffix),
+			"Выполните git rebase -i --autosquash перед слиянием")}
+	}
+
+	if wipPattern.MatchString(commit.Subject) {
+		return []types.Issue{commitIssue(severity,
+			fmt.Sprintf("Незавершенный (WIP) коммит%s", suffix),
+			"Завершите изменения и объедините WIP коммиты с основными перед слиянием")}
+	}
+
+	return nil
+}
+
+// CheckMessageMatchesDiff просит модель сравнить сообщение коммита с фактическими изменениями
+func (a *CommitAnalyzer) CheckMessageMatchesDiff(commit git.Commit, diff string) ([]types.Issue, error) {
+	if len(diff) > maxCommitDiffSize {
+		diff = strings.ToValidUTF8(diff[:maxCommitDiffSize], "") + "\n... (diff обрезан)"
+	}
+
+	response, err := a.ollamaClient.Generate(buildCommitPrompt(commit, diff))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка AI-анализа коммита: %v", err)
+	}
+
+	jsonData := extractJSONFromResponse(response)
+	if jsonData == "" {
+		return nil, nil
+	}
+
+	var result struct {
+		Matches    *bool  `json:"matches"`
+		Message    string `json:"message"`
+		Suggestion string `json:"suggestion"`
+		Reasoning  string `json:"reasoning"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil || result.Matches == nil || *result.Matches {
+		return nil, nil
+	}
+
+	message := result.Message
+	if message == "" {
+		message = "Сообщение коммита не описывает внесенные изменения"
+	}
+	suggestion := result.Suggestion
+	if suggestion == "" {
+		suggestion = "Опишите в сообщении, что и зачем изменено"
+	}
+
+	issue := commitIssue("medium", message, suggestion)
+	issue.Reasoning = result.Reasoning
+	return []types.Issue{issue}, nil
+}
+
+// buildCommitPrompt строит промпт проверки соответствия сообщения и изменений
+func buildCommitPrompt(commit git.Commit, diff string) string {
+	var stats strings.Builder
+	for _, file := range commit.Files {
+		stats.WriteString(fmt.Sprintf("  %s (+%d -%d)\n", file.Path, file.Additions, file.Deletions))
+	}
+
+	return fmt.Sprintf(`Ты - опытный ревьюер. Проверь, соответствует ли сообщение коммита внесенным изменениям.
+
+СООБЩЕНИЕ КОММИТА:
+%s
+
+ИЗМЕНЕННЫЕ ФАЙЛЫ:
+%s
+DIFF:
+%s
+
+Сообщение соответствует изменениям, если оно верно описывает их суть. Не придирайся к стилю.
+
+ОТВЕТЬ ТОЛЬКО В ФОРМАТЕ JSON БЕЗ ДОПОЛНИТЕЛЬНОГО ТЕКСТА:
+{
+  "matches": false,
+  "message": "Что именно не совпадает",
+  "suggestion": "Предлагаемое сообщение коммита",
+  "reasoning": "Почему сообщение не соответствует изменениям"
+}`, commit.Message(), stats.String(), diff)
+}
+
+// IsFixupCommit проверяет, является ли коммит fixup!/squash!/amend! коммитом
+func IsFixupCommit(commit git.Commit) bool {
+	return fixupPattern.MatchString(commit.Subject)
+}
+
+// IsDefaultMergeCommit проверяет, является ли коммит merge-коммитом со стандартным
+// заголовком, который сформировал git или хостинг репозитория
+func IsDefaultMergeCommit(commit git.Commit) bool {
+	return commit.IsMerge() && mergeSubjectPattern.MatchString(commit.Subject)
+}
+
+// ChangedAreas возвращает области кода, затронутые коммитом: каталог первого
+// уровня, а для cmd/internal/pkg/src - первые два уровня. Файлы в корне проекта
+// (README, go.mod, конфигурация) обычно сопровождают изменения и не считаются.
+func ChangedAreas(commit git.Commit) []string {
+	var areas []string
+	for _, file := range commit.Files {
+		dir := path.Dir(file.Path)
+		if dir == "." {
+			continue
+		}
+		parts := strings.Split(dir, "/")
+
+		area := parts[0]
+		if len(parts) > 1 && containsString([]string{"cmd", "internal", "pkg", "src", "lib"}, parts[0]) {
+			area = parts[0] + "/" + parts[1]
+		}
+		if !containsString(areas, area) {
+			areas = append(areas, area)
+		}
+	}
+	return areas
+}
+
+// commitIssue создает проблему коммита
+func commitIssue(severity, message, suggestion string) types.Issue {
+	return types.Issue{
+		Type:       "commit",
+		Severity:   severity,
+		Message:    message,
+		Suggestion: suggestion,
+	}
+}
+
+// containsString проверяет наличие строки в срезе
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package analyzer
+
+import (
+	"testing"
+
+	"miniReviewer/internal/git"
+)
+
+func TestCheckMessageDefaultMergeSubjects(t *testing.T) {
+	a := &CommitAnalyzer{options: CommitAnalysisOptions{ConventionalTypes: []string{"feat", "fix"}, MaxSubjectLength: 50}}
+	merge := []string{"parent1", "parent2"}
+
+	tests := []struct {
+		subject string
+		parents []string
+		want    int
+	}{
+		{"Merge branch 'feature/very-long-branch-name-for-the-subject' into main", merge, 0},
+		{"Merge pull request #42 from user/feature", merge, 0},
+		{"Merge remote-tracking branch 'origin/main'", merge, 0},
+		{"Merge tag 'v1.2.0'", merge, 0},
+		{"Merge branch 'x'", []string{"parent1"}, 1},
+		{"merged some stuff", merge, 1},
+		{"fix: handle merge conflicts", merge, 0},
+	}
+
+	for _, tt := range tests {
+		issues := a.CheckMessage(git.Commit{Subject: tt.subject, Parents: tt.parents})
+		if len(issues) != tt.want {
+			t.Errorf("%q: got %d issues, want %d", tt.subject, len(issues), tt.want)
+		}
+	}
+}
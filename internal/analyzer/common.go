@@ -1,6 +1,8 @@
 package analyzer
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -33,6 +35,28 @@ func detectLanguage(context string) string {
 	return "код"
 }
 
+// ContentHash возвращает SHA-256 содержимого файла в шестнадцатеричном виде
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SeverityRank возвращает числовой ранг важности (чем больше, тем важнее)
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	default:
+		return 0
+	}
+}
+
 // extractJSONFromResponse извлекает JSON из ответа AI
 func extractJSONFromResponse(response string) string {
 	// Убираем лишние пробелы и переносы строк
@@ -203,14 +227,35 @@ func PrintIssues(issues []types.Issue, verbose bool) {
 // PrintFileIssues выводит проблемы для одного файла
 func PrintFileIssues(result *types.CodeAnalysisResult, verbose bool) {
 	fmt.Printf("\n📁 %s:\n", result.File)
-	for _, issue := range result.Issues {
+
+	// При пофункциональном анализе группируем проблемы по функциям
+	if HasFunctionInfo(result.Issues) {
+		order, groups := GroupIssuesByFunction(result.Issues)
+		for _, function := range order {
+			name := function
+			if name == "" {
+				name = "вне функций"
+			}
+			fmt.Printf("  🔧 %s (%d):\n", name, len(groups[function]))
+			printIssueLines(groups[function], "  ", verbose)
+		}
+		return
+	}
+
+	printIssueLines(result.Issues, "", verbose)
+}
+
+// printIssueLines выводит проблемы файла с дополнительным отступом
+func printIssueLines(issues []types.Issue, indent string, verbose bool) {
+	for _, issue := range issues {
+		fmt.Print(indent)
 		if verbose {
 			// Подробный вывод с размышлениями модели
 			fmt.Printf("  ⚠️  [%s] %s (строка %d):\n", strings.ToUpper(issue.Severity), issue.Type, issue.Line)
-			fmt.Printf("     💬 %s\n", issue.Message)
-			fmt.Printf("     💡 %s\n", issue.Suggestion)
+			fmt.Printf("%s     💬 %s\n", indent, issue.Message)
+			fmt.Printf("%s     💡 %s\n", indent, issue.Suggestion)
 			if issue.Reasoning != "" {
-				fmt.Printf("     🧠 %s\n", issue.Reasoning)
+				fmt.Printf("%s     🧠 %s\n", indent, issue.Reasoning)
 			}
 		} else {
 			// Краткий вывод - только проблема и строка
@@ -361,3 +406,15 @@ func saveAnalysisResultsBase(results interface{}, output string, verbose bool, a
 		fmt.Printf("\n💾 Результаты %s сохранены в: %s\n", analyzerName, output)
 	}
 }
+
+// buildRelatedSection формирует раздел промпта со связанными объявлениями
+func buildRelatedSection(related string) string {
+	if strings.TrimSpace(related) == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+СВЯЗАННЫЕ ОБЪЯВЛЕНИЯ ИЗ ДРУГИХ ФАЙЛОВ (только для справки, не анализируй их и не считай эти символы неопределенными):
+%s
+`, related)
+}
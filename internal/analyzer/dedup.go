@@ -0,0 +1,322 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// Embedder источник векторных представлений текста для сравнения сообщений
+type Embedder interface {
+	Embed(text string) ([]float64, error)
+}
+
+// Deduplicator объединяет похожие проблемы от разных анализаторов
+type Deduplicator struct {
+	similarityThreshold float64
+	embeddingThreshold  float64
+	lineTolerance       int
+	embedder            Embedder
+	embeddings          map[string][]float64
+}
+
+// issueCluster группа проблем, признанных дубликатами
+type issueCluster struct {
+	issue      types.Issue
+	categories []string
+	messages   []string
+	lines      map[string][]int // строки проблем группы по категориям
+}
+
+// NewDeduplicator создает дедупликатор с настройками из конфигурации.
+// Эмбеддинги Ollama используются только при включенном dedup.use_embeddings.
+func NewDeduplicator() *Deduplicator {
+	d := &Deduplicator{
+		similarityThreshold: viper.GetFloat64("dedup.similarity_threshold"),
+		embeddingThreshold:  viper.GetFloat64("dedup.embedding_threshold"),
+		lineTolerance:       viper.GetInt("dedup.line_tolerance"),
+		embeddings:          make(map[string][]float64),
+	}
+
+	if viper.GetBool("dedup.use_embeddings") {
+		d.embedder = ollama.NewClient()
+	}
+
+	return d
+}
+
+// DeduplicateResults объединяет дубликаты в каждом результате
+func (d *Deduplicator) DeduplicateResults(results []*types.CodeAnalysisResult) {
+	for _, result := range results {
+		d.DeduplicateResult(result)
+	}
+}
+
+// DeduplicateResult объединяет дубликаты в результате и увеличивает счетчик объединенных проблем
+func (d *Deduplicator) DeduplicateResult(result *types.CodeAnalysisResult) {
+	if result == nil {
+		return
+	}
+
+	issues, collapsed := d.Deduplicate(result.Issues, result.File)
+	result.Issues = issues
+	result.DuplicatesCollapsed += collapsed
+}
+
+// Deduplicate группирует проблемы одного файла с пересекающимися строками и похожими
+// сообщениями. Из группы остается проблема с наибольшей важностью, в Categories
+// перечисляются все категории, сообщившие о ней. Возвращает число объединенных дубликатов.
+func (d *Deduplicator) Deduplicate(issues []types.Issue, defaultFile string) ([]types.Issue, int) {
+	if len(issues) < 2 {
+		return issues, 0
+	}
+
+	var clusters []*issueCluster
+	collapsed := 0
+
+	for _, issue := range issues {
+		if cluster := d.findCluster(clusters, issue, defaultFile); cluster != nil {
+			cluster.add(issue)
+			collapsed++
+			continue
+		}
+
+		clusters = append(clusters, newIssueCluster(issue))
+	}
+
+	if collapsed == 0 {
+		return issues, 0
+	}
+
+	deduplicated := make([]types.Issue, 0, len(clusters))
+	for _, cluster := range clusters {
+		deduplicated = append(deduplicated, cluster.result())
+	}
+
+	return deduplicated, collapsed
+}
+
+// findCluster ищет группу, к которой относится проблема. Проблемы одной категории
+// на соседних строках - разные находки одного анализатора, поэтому с группой,
+// где уже есть проблема той же категории, объединяется только проблема на той же строке.
+func (d *Deduplicator) findCluster(clusters []*issueCluster, issue types.Issue, defaultFile string) *issueCluster {
+	for _, cluster := range clusters {
+		if issueFile(cluster.issue, defaultFile) != issueFile(issue, defaultFile) {
+			continue
+		}
+		if !d.linesOverlap(cluster.issue.Line, issue.Line) {
+			continue
+		}
+		if cluster.hasOtherLine(issue) {
+			continue
+		}
+		for _, message := range cluster.messages {
+			if d.similar(message, issue.Message) {
+				return cluster
+			}
+		}
+	}
+	return nil
+}
+
+// linesOverlap проверяет, относятся ли проблемы к одному месту кода.
+// Проблемы без номера строки совпадают только с такими же проблемами.
+func (d *Deduplicator) linesOverlap(a, b int) bool {
+	if a <= 0 || b <= 0 {
+		return a <= 0 && b <= 0
+	}
+
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= d.lineTolerance
+}
+
+// similar сравнивает сообщения: по токенам и, если доступно, по эмбеддингам
+func (d *Deduplicator) similar(a, b string) bool {
+	if TokenSimilarity(a, b) >= d.similarityThreshold {
+		return true
+	}
+
+	if d.embedder == nil {
+		return false
+	}
+
+	first, err := d.embed(a)
+	if err != nil {
+		return false
+	}
+	second, err := d.embed(b)
+	if err != nil {
+		return false
+	}
+
+	return CosineSimilarity(first, second) >= d.embeddingThreshold
+}
+
+// embed возвращает эмбеддинг сообщения с кэшированием. При ошибке эмбеддинги
+// отключаются до конца работы, и сравнение продолжается только по токенам.
+func (d *Deduplicator) embed(text string) ([]float64, error) {
+	if embedding, exists := d.embeddings[text]; exists {
+		return embedding, nil
+	}
+
+	embedding, err := d.embedder.Embed(text)
+	if err != nil {
+		d.embedder = nil
+		return nil, err
+	}
+
+	d.embeddings[text] = embedding
+	return embedding, nil
+}
+
+// TokenSimilarity вычисляет коэффициент Жаккара для множеств слов двух сообщений
+func TokenSimilarity(a, b string) float64 {
+	first := messageTokens(a)
+	second := messageTokens(b)
+	if len(first) == 0 || len(second) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range first {
+		if second[token] {
+			intersection++
+		}
+	}
+
+	union := len(first) + len(second) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// messageTokens разбивает нормализованное сообщение на значимые слова.
+// Слова усекаются до основы из 6 символов, чтобы сглаживать окончания.
+func messageTokens(message string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, word := range strings.Fields(NormalizeMessage(message)) {
+		runes := []rune(word)
+		if len(runes) < 3 {
+			continue
+		}
+		if len(runes) > 6 {
+			runes = runes[:6]
+		}
+		tokens[string(runes)] = true
+	}
+	return tokens
+}
+
+// CosineSimilarity вычисляет косинусное сходство двух векторов
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// issueFile возвращает файл проблемы с учетом файла результата
+func issueFile(issue types.Issue, defaultFile string) string {
+	if issue.File != "" {
+		return issue.File
+	}
+	return defaultFile
+}
+
+// newIssueCluster создает группу из одной проблемы
+func newIssueCluster(issue types.Issue) *issueCluster {
+	cluster := &issueCluster{issue: issue, lines: make(map[string][]int)}
+	cluster.addCategories(issue)
+	cluster.messages = append(cluster.messages, issue.Message)
+	return cluster
+}
+
+// add добавляет дубликат в группу; представителем становится проблема с наибольшей важностью
+func (c *issueCluster) add(issue types.Issue) {
+	c.addCategories(issue)
+	c.messages = append(c.messages, issue.Message)
+
+	if SeverityRank(issue.Severity) > SeverityRank(c.issue.Severity) {
+		representative := issue
+		// Сохраняем сведения, которых нет у более важной проблемы
+		if representative.Function == "" {
+			representative.Function = c.issue.Function
+		}
+		if representative.CWE == "" {
+			representative.CWE = c.issue.CWE
+			representative.OWASP = c.issue.OWASP
+		}
+		c.issue = representative
+	} else if c.issue.CWE == "" && issue.CWE != "" {
+		c.issue.CWE = issue.CWE
+		c.issue.OWASP = issue.OWASP
+	}
+}
+
+// hasOtherLine проверяет, есть ли в группе проблема той же категории на другой строке
+func (c *issueCluster) hasOtherLine(issue types.Issue) bool {
+	for _, category := range issueCategories(issue) {
+		for _, line := range c.lines[category] {
+			if line != issue.Line {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// issueCategories возвращает категории проблемы: Categories или тип проблемы
+func issueCategories(issue types.Issue) []string {
+	if len(issue.Categories) > 0 {
+		return issue.Categories
+	}
+	return []string{issue.Type}
+}
+
+// addCategories добавляет категории проблемы без повторов
+func (c *issueCluster) addCategories(issue types.Issue) {
+	for _, category := range issueCategories(issue) {
+		if category == "" {
+			continue
+		}
+		c.lines[category] = append(c.lines[category], issue.Line)
+		exists := false
+		for _, existing := range c.categories {
+			if existing == category {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			c.categories = append(c.categories, category)
+		}
+	}
+}
+
+// result возвращает итоговую проблему группы
+func (c *issueCluster) result() types.Issue {
+	issue := c.issue
+	if len(c.messages) > 1 {
+		categories := append([]string(nil), c.categories...)
+		sort.Strings(categories)
+		issue.Categories = categories
+	}
+	return issue
+}
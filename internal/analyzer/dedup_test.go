@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"testing"
+
+	"miniReviewer/internal/types"
+)
+
+func newTestDeduplicator() *Deduplicator {
+	return &Deduplicator{
+		similarityThreshold: 0.5,
+		lineTolerance:       2,
+		embeddings:          make(map[string][]float64),
+	}
+}
+
+func TestDeduplicateMergesCategories(t *testing.T) {
+	issues := []types.Issue{
+		{Type: "quality", Severity: "medium", Message: "Ошибка записи файла не проверяется", Line: 10},
+		{Type: "security", Severity: "high", Message: "Ошибка записи файла не проверяется и теряется", Line: 11},
+	}
+
+	deduplicated, collapsed := newTestDeduplicator().Deduplicate(issues, "main.go")
+	if collapsed != 1 || len(deduplicated) != 1 {
+		t.Fatalf("got %d issues, %d collapsed; want 1 issue, 1 collapsed", len(deduplicated), collapsed)
+	}
+	if got := deduplicated[0].Categories; len(got) != 2 || got[0] != "quality" || got[1] != "security" {
+		t.Errorf("categories = %v, want [quality security]", got)
+	}
+}
+
+func TestDeduplicateKeepsSameCategoryOnAdjacentLines(t *testing.T) {
+	issues := []types.Issue{
+		{Type: "quality", Severity: "medium", Message: "Ошибка записи файла не проверяется", Line: 10},
+		{Type: "quality", Severity: "medium", Message: "Ошибка записи файла не проверяется", Line: 11},
+	}
+
+	deduplicated, collapsed := newTestDeduplicator().Deduplicate(issues, "main.go")
+	if collapsed != 0 || len(deduplicated) != 2 {
+		t.Fatalf("got %d issues, %d collapsed; want 2 issues, 0 collapsed", len(deduplicated), collapsed)
+	}
+}
+
+func TestDeduplicateMergesSameCategoryOnSameLine(t *testing.T) {
+	issues := []types.Issue{
+		{Type: "quality", Severity: "low", Message: "Ошибка записи файла не проверяется", Line: 10},
+		{Type: "quality", Severity: "medium", Message: "Ошибка записи файла не проверяется", Line: 10},
+	}
+
+	deduplicated, collapsed := newTestDeduplicator().Deduplicate(issues, "main.go")
+	if collapsed != 1 || len(deduplicated) != 1 {
+		t.Fatalf("got %d issues, %d collapsed; want 1 issue, 1 collapsed", len(deduplicated), collapsed)
+	}
+	if deduplicated[0].Severity != "medium" {
+		t.Errorf("severity = %s, want medium", deduplicated[0].Severity)
+	}
+}
@@ -0,0 +1,223 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// ChangeDescriber генератор сообщений коммитов и описаний PR по diff
+type ChangeDescriber struct {
+	ollamaClient      *ollama.Client
+	conventionalTypes []string
+	maxSubjectLength  int
+	maxDiffSize       int
+	language          string
+}
+
+// NewChangeDescriber создает генератор с настройками из секций describe и commits
+func NewChangeDescriber() *ChangeDescriber {
+	return &ChangeDescriber{
+		ollamaClient:      ollama.NewClient(),
+		conventionalTypes: viper.GetStringSlice("commits.conventional_types"),
+		maxSubjectLength:  viper.GetInt("commits.max_subject_length"),
+		maxDiffSize:       viper.GetInt("describe.max_diff_size"),
+		language:          viper.GetString("describe.language"),
+	}
+}
+
+// Describe генерирует сообщение коммита в формате Conventional Commits и описание
+// PR по изменениям файлов. commits - коммиты описываемых изменений (пусто для индекса).
+func (d *ChangeDescriber) Describe(files []git.FileDiff, commits []git.Commit) (*types.ChangeDescription, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("нет изменений для описания")
+	}
+
+	// В промпт попадает только содержимое изменений: бинарные и удаленные файлы,
+	// переименования и смены режима описываются в списке файлов
+	var changes strings.Builder
+	for _, file := range files {
+		if file.Binary || file.IsDeleted() || len(file.Hunks) == 0 {
+			continue
+		}
+		changes.WriteString(fmt.Sprintf("=== %s\n%s\n", file.Path(), file.Annotated()))
+	}
+	diff := changes.String()
+	if d.maxDiffSize > 0 && len(diff) > d.maxDiffSize {
+		diff = strings.ToValidUTF8(diff[:d.maxDiffSize], "") + "\n... (diff обрезан)"
+	}
+
+	response, err := d.ollamaClient.Generate(d.buildDescribePrompt(files, diff, commits))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации описания: %v", err)
+	}
+
+	jsonData := extractJSONFromResponse(response)
+	if jsonData == "" {
+		return nil, fmt.Errorf("модель не вернула описание изменений в формате JSON")
+	}
+
+	var description types.ChangeDescription
+	if err := json.Unmarshal([]byte(jsonData), &description); err != nil {
+		return nil, fmt.Errorf("ошибка разбора описания изменений: %v", err)
+	}
+	if strings.TrimSpace(description.Subject) == "" {
+		return nil, fmt.Errorf("модель не сгенерировала заголовок коммита")
+	}
+
+	for _, commit := range commits {
+		description.Commits = append(description.Commits, commit.ShortHash+" "+commit.Subject)
+	}
+	d.normalize(&description)
+	return &description, nil
+}
+
+// normalize приводит ответ модели к формату Conventional Commits: допустимый
+// тип, заголовок без точки в конце и не длиннее commits.max_subject_length
+func (d *ChangeDescriber) normalize(description *types.ChangeDescription) {
+	description.Type = strings.ToLower(strings.TrimSpace(description.Type))
+	if len(d.conventionalTypes) > 0 && !containsString(d.conventionalTypes, description.Type) {
+		description.Type = d.conventionalTypes[0]
+		if containsString(d.conventionalTypes, "chore") {
+			description.Type = "chore"
+		}
+	}
+	if description.Type == "" {
+		description.Type = "chore"
+	}
+
+	description.Scope = strings.Trim(strings.TrimSpace(description.Scope), "()")
+	description.Subject = strings.TrimSuffix(strings.TrimSpace(description.Subject), ".")
+	description.Body = strings.TrimSpace(description.Body)
+
+	if d.maxSubjectLength > 0 {
+		prefix := utf8.RuneCountInString(CommitHeader(description)) - utf8.RuneCountInString(description.Subject)
+		description.Subject = truncateWords(description.Subject, d.maxSubjectLength-prefix)
+	}
+
+	if strings.TrimSpace(description.Title) == "" {
+		description.Title = CommitHeader(description)
+	}
+	if strings.TrimSpace(description.Summary) == "" {
+		description.Summary = description.Body
+	}
+}
+
+// CommitHeader возвращает заголовок коммита "тип(область)!: описание"
+func CommitHeader(description *types.ChangeDescription) string {
+	header := description.Type
+	if description.Scope != "" {
+		header += "(" + description.Scope + ")"
+	}
+	if description.Breaking {
+		header += "!"
+	}
+	return header + ": " + description.Subject
+}
+
+// CommitMessage возвращает полное сообщение коммита: заголовок и тело
+func CommitMessage(description *types.ChangeDescription) string {
+	message := CommitHeader(description)
+	if description.Body != "" {
+		message += "\n\n" + description.Body
+	}
+	return message
+}
+
+// buildDescribePrompt строит промпт генерации сообщения коммита и описания PR
+func (d *ChangeDescriber) buildDescribePrompt(files []git.FileDiff, diff string, commits []git.Commit) string {
+	var stats strings.Builder
+	for _, file := range files {
+		added, deleted := 0, 0
+		for _, hunk := range file.Hunks {
+			for _, line := range hunk.Lines {
+				switch line.Kind {
+				case '+':
+					added++
+				case '-':
+					deleted++
+				}
+			}
+		}
+
+		status := ""
+		if summary := file.Summary(); summary != "" {
+			status = " (" + summary + ")"
+		}
+		stats.WriteString(fmt.Sprintf("  %s (+%d -%d)%s\n", file.Path(), added, deleted, status))
+	}
+
+	var history strings.Builder
+	if len(commits) > 0 {
+		history.WriteString("\nКОММИТЫ:\n")
+		for _, commit := range commits {
+			history.WriteString(fmt.Sprintf("  %s\n", commit.Subject))
+		}
+	}
+
+	allowedTypes := "feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert"
+	if len(d.conventionalTypes) > 0 {
+		allowedTypes = strings.Join(d.conventionalTypes, ", ")
+	}
+	subjectLength := d.maxSubjectLength
+	if subjectLength <= 0 {
+		subjectLength = 72
+	}
+	language := d.language
+	if language == "" {
+		language = "English"
+	}
+
+	return fmt.Sprintf(`Ты - опытный разработчик. Опиши изменения для сообщения коммита и описания Pull Request.
+
+ИЗМЕНЕННЫЕ ФАЙЛЫ:
+%s%s
+ИЗМЕНЕНИЯ (номер строки в новой версии, '+' добавлено, '-' удалено):
+%s
+
+ТРЕБОВАНИЯ:
+1. type - один из типов Conventional Commits: %s
+2. scope - затронутая область кода (пакет, модуль) или пустая строка
+3. subject - краткое описание в повелительном наклонении, без точки в конце, до %d символов
+4. body - что и зачем изменено, 1-3 предложения; пустая строка для очевидных изменений
+5. breaking - true, если изменения ломают обратную совместимость
+6. summary - описание изменений для ревьюеров
+7. risk_areas - места, требующие внимательного ревью: поведение, совместимость, безопасность, производительность
+8. testing_notes - как проверить изменения
+9. Пиши тексты на языке: %s
+10. Описывай только то, что видно в diff, ничего не придумывай
+
+ОТВЕТЬ ТОЛЬКО В ФОРМАТЕ JSON БЕЗ ДОПОЛНИТЕЛЬНОГО ТЕКСТА:
+{
+  "type": "feat",
+  "scope": "git",
+  "subject": "add merge base lookup",
+  "body": "Что и зачем изменено",
+  "breaking": false,
+  "title": "Заголовок Pull Request",
+  "summary": "Описание изменений",
+  "risk_areas": ["Область риска"],
+  "testing_notes": ["Как проверить"]
+}`, stats.String(), history.String(), diff, allowedTypes, subjectLength, language)
+}
+
+// truncateWords обрезает строку до limit символов по границе слова
+func truncateWords(value string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+
+	runes := []rune(value)[:limit]
+	truncated := string(runes)
+	if space := strings.LastIndex(truncated, " "); space > 0 {
+		truncated = truncated[:space]
+	}
+	return strings.TrimRight(truncated, " ,;:-")
+}
@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+// fingerprintContextLines количество строк до и после проблемной строки,
+// участвующих в отпечатке
+const fingerprintContextLines = 2
+
+var (
+	numberPattern      = regexp.MustCompile(`\d+`)
+	punctuationPattern = regexp.MustCompile(`[^\p{L}\p{N}_\s]+`)
+	spacePattern       = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeMessage приводит сообщение к виду, устойчивому к мелким изменениям:
+// нижний регистр, без чисел (номеров строк), пунктуации и лишних пробелов
+func NormalizeMessage(message string) string {
+	normalized := strings.ToLower(message)
+	normalized = numberPattern.ReplaceAllString(normalized, "")
+	normalized = punctuationPattern.ReplaceAllString(normalized, " ")
+	normalized = spacePattern.ReplaceAllString(normalized, " ")
+	return strings.TrimSpace(normalized)
+}
+
+// Fingerprint вычисляет стабильный идентификатор проблемы. Вместо номера строки
+// используется хеш окружающих строк кода, поэтому отпечаток не меняется при сдвиге кода.
+func Fingerprint(file string, issue types.Issue, lines []string) string {
+	h := sha256.New()
+	h.Write([]byte(filepath.ToSlash(file)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToLower(issue.Type)))
+	h.Write([]byte{0})
+	h.Write([]byte(NormalizeMessage(issue.Message)))
+	h.Write([]byte{0})
+	h.Write([]byte(surroundingCodeHash(lines, issue.Line)))
+
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// surroundingCodeHash хеширует строки вокруг проблемной строки без учета отступов
+func surroundingCodeHash(lines []string, line int) string {
+	if line <= 0 || line > len(lines) {
+		return ""
+	}
+
+	start := line - 1 - fingerprintContextLines
+	if start < 0 {
+		start = 0
+	}
+	end := line + fingerprintContextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var normalized []string
+	for _, l := range lines[start:end] {
+		normalized = append(normalized, strings.TrimSpace(l))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(normalized, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AssignFingerprints вычисляет отпечатки для всех проблем в результатах.
+// Содержимое файлов читается с диска; если файл недоступен, отпечаток
+// строится без учета окружающего кода.
+func AssignFingerprints(results []*types.CodeAnalysisResult) {
+	AssignFingerprintsFrom(results, os.ReadFile)
+}
+
+// AssignFingerprintsFrom вычисляет отпечатки для всех проблем в результатах,
+// получая содержимое файлов функцией read (например, из ревизии git)
+func AssignFingerprintsFrom(results []*types.CodeAnalysisResult, read func(file string) ([]byte, error)) {
+	cache := make(map[string][]string)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		assignIssueFingerprints(result.Issues, result.File, read, cache)
+	}
+}
+
+// AssignIssueFingerprints вычисляет отпечатки для списка проблем
+func AssignIssueFingerprints(issues []types.Issue, defaultFile string) {
+	assignIssueFingerprints(issues, defaultFile, os.ReadFile, make(map[string][]string))
+}
+
+// assignIssueFingerprints вычисляет отпечатки, кэшируя строки прочитанных файлов
+func assignIssueFingerprints(issues []types.Issue, defaultFile string, read func(string) ([]byte, error), cache map[string][]string) {
+	for i := range issues {
+		issue := &issues[i]
+
+		file := issue.File
+		if file == "" {
+			file = defaultFile
+		}
+
+		lines, cached := cache[file]
+		if !cached {
+			if content, err := read(file); err == nil {
+				lines = strings.Split(string(content), "\n")
+			}
+			cache[file] = lines
+		}
+
+		issue.Fingerprint = Fingerprint(file, *issue, lines)
+	}
+}
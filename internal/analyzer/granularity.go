@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"miniReviewer/internal/chunker"
+	"miniReviewer/internal/types"
+)
+
+// Режимы гранулярности анализа
+const (
+	GranularityFile     = "file"
+	GranularityFunction = "function"
+)
+
+// RelatedAnalyzer анализатор, учитывающий связанные объявления из других файлов
+type RelatedAnalyzer interface {
+	AnalyzeWithRelated(code, context, related string) (*types.CodeAnalysisResult, error)
+}
+
+// ValidateGranularity проверяет режим гранулярности
+func ValidateGranularity(granularity string) error {
+	switch granularity {
+	case GranularityFile, GranularityFunction:
+		return nil
+	default:
+		return fmt.Errorf("неизвестный режим гранулярности %q (допустимо: %s, %s)", granularity, GranularityFile, GranularityFunction)
+	}
+}
+
+// AnalyzeWithGranularity анализирует файл целиком или по отдельным функциям.
+// Пофункциональный режим поддерживается для Go файлов; для остальных языков
+// и при ошибке разбора файл анализируется целиком.
+func AnalyzeWithGranularity(a RelatedAnalyzer, file string, content []byte, context, related, granularity string, verbose bool) (*types.CodeAnalysisResult, error) {
+	if granularity != GranularityFunction || !strings.HasSuffix(file, ".go") {
+		return a.AnalyzeWithRelated(string(content), context, related)
+	}
+
+	chunks, err := chunker.SplitGoFile(file, content)
+	if err != nil || len(chunks) == 0 {
+		if verbose && err != nil {
+			fmt.Printf("   ⚠️  Не удалось разбить файл на функции, анализирую целиком: %v\n", err)
+		}
+		return a.AnalyzeWithRelated(string(content), context, related)
+	}
+
+	combined := &types.CodeAnalysisResult{
+		File:      file,
+		Issues:    []types.Issue{},
+		Timestamp: time.Now(),
+	}
+
+	weightedScore := 0
+	totalLines := 0
+
+	for i, chunk := range chunks {
+		if verbose {
+			fmt.Printf("   🔹 [%d/%d] %s (строки %d-%d)\n", i+1, len(chunks), chunk.Name, chunk.StartLine, chunk.EndLine)
+		}
+
+		result, err := a.AnalyzeWithRelated(chunk.Code, buildChunkContext(context, chunk), related)
+		if err != nil {
+			if verbose {
+				fmt.Printf("      ⚠️  Ошибка анализа %s: %v\n", chunk.Name, err)
+			}
+			continue
+		}
+
+		for _, issue := range result.Issues {
+			issue.Line = chunk.ToAbsoluteLine(issue.Line)
+			issue.Function = chunk.Name
+			combined.Issues = append(combined.Issues, issue)
+		}
+
+		weightedScore += result.Score * chunk.LineCount()
+		totalLines += chunk.LineCount()
+	}
+
+	if totalLines == 0 {
+		return nil, fmt.Errorf("не удалось проанализировать ни одной функции в %s", file)
+	}
+
+	combined.Score = weightedScore / totalLines
+	return combined, nil
+}
+
+// buildChunkContext формирует контекст для анализа отдельного объявления
+func buildChunkContext(context string, chunk chunker.Chunk) string {
+	var b strings.Builder
+	b.WriteString(context)
+	b.WriteString(fmt.Sprintf("\nФрагмент: %s %s (строки %d-%d файла). Номера строк указывай относительно начала фрагмента.", chunk.Kind, chunk.Name, chunk.StartLine, chunk.EndLine))
+	if chunk.Receiver != "" {
+		b.WriteString(fmt.Sprintf("\nТип-получатель: %s", chunk.Receiver))
+	}
+	if chunk.Doc != "" {
+		b.WriteString(fmt.Sprintf("\nDoc-комментарий: %s", chunk.Doc))
+	}
+	return b.String()
+}
+
+// GroupIssuesByFunction группирует проблемы по функциям с сохранением порядка появления.
+// Проблемы без функции попадают в группу с пустым именем.
+func GroupIssuesByFunction(issues []types.Issue) ([]string, map[string][]types.Issue) {
+	var order []string
+	groups := make(map[string][]types.Issue)
+	for _, issue := range issues {
+		if _, exists := groups[issue.Function]; !exists {
+			order = append(order, issue.Function)
+		}
+		groups[issue.Function] = append(groups[issue.Function], issue)
+	}
+	return order, groups
+}
+
+// HasFunctionInfo проверяет, привязаны ли проблемы к функциям
+func HasFunctionInfo(issues []types.Issue) bool {
+	for _, issue := range issues {
+		if issue.Function != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/types"
+)
+
+// NewTrendPoint сводит результаты анализа изменений коммита в точку истории
+func NewTrendPoint(commit git.Commit, results []*types.CodeAnalysisResult) types.CommitTrendPoint {
+	point := types.CommitTrendPoint{
+		Hash:       commit.Hash,
+		ShortHash:  commit.ShortHash,
+		Author:     commit.Author,
+		Date:       commit.Date,
+		Subject:    commit.Subject,
+		Files:      len(results),
+		Skipped:    len(results) == 0,
+		BySeverity: make(map[string]int),
+		Results:    results,
+	}
+
+	totalScore := 0
+	for _, result := range results {
+		totalScore += result.Score
+		point.Issues += len(result.Issues)
+		for _, issue := range result.Issues {
+			point.BySeverity[issue.Severity]++
+		}
+	}
+	if len(results) > 0 {
+		point.Score = totalScore / len(results)
+	}
+
+	return point
+}
+
+// MarkRegressions отмечает коммиты, ухудшившие качество: с проблемами важности
+// high и выше или с оценкой ниже предыдущего проанализированного коммита
+// не меньше чем на scoreDrop баллов
+func MarkRegressions(points []types.CommitTrendPoint, scoreDrop int) {
+	previous := -1
+	for i := range points {
+		point := &points[i]
+		if point.Skipped {
+			continue
+		}
+
+		severe := point.BySeverity["critical"] + point.BySeverity["high"]
+		dropped := previous >= 0 && scoreDrop > 0 && previous-point.Score >= scoreDrop
+		point.Regression = severe > 0 || dropped
+		previous = point.Score
+	}
+}
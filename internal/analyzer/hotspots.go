@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/git"
+	"miniReviewer/internal/types"
+)
+
+// decisionPattern операторы ветвления, по которым оценивается сложность файла
+// (приближение цикломатической сложности, не зависящее от языка)
+var decisionPattern = regexp.MustCompile(`\b(?:if|elif|else if|for|foreach|while|case|catch|except)\b|&&|\|\||\?\?`)
+
+// CollectChurn собирает по коммитам частоту изменений, количество авторов и
+// объем изменений каждого файла. Коммиты ожидаются от новых к старым: изменения
+// до переименования файла учитываются под его текущим путем.
+func CollectChurn(commits []git.Commit) map[string]*types.Hotspot {
+	hotspots := make(map[string]*types.Hotspot)
+	authors := make(map[string]map[string]bool)
+	renamed := make(map[string]string) // старый путь -> текущий путь
+
+	for _, commit := range commits {
+		for _, file := range commit.Files {
+			if file.Binary {
+				continue
+			}
+
+			path := file.Path
+			if current, ok := renamed[path]; ok {
+				path = current
+			}
+			if file.OldPath != "" {
+				renamed[file.OldPath] = path
+			}
+
+			hotspot, exists := hotspots[path]
+			if !exists {
+				hotspot = &types.Hotspot{File: path, LastChanged: commit.Date}
+				hotspots[path] = hotspot
+				authors[path] = make(map[string]bool)
+			}
+
+			hotspot.Commits++
+			hotspot.ChurnLines += file.Additions + file.Deletions
+			if commit.Date.After(hotspot.LastChanged) {
+				hotspot.LastChanged = commit.Date
+			}
+
+			author := strings.ToLower(commit.Email)
+			if author == "" {
+				author = commit.Author
+			}
+			authors[path][author] = true
+		}
+	}
+
+	for path, hotspot := range hotspots {
+		hotspot.Authors = len(authors[path])
+	}
+	return hotspots
+}
+
+// MeasureFile заполняет размер и сложность файла по его содержимому
+func MeasureFile(hotspot *types.Hotspot, content []byte) {
+	text := string(content)
+	hotspot.Lines = strings.Count(text, "\n")
+	if len(text) > 0 && !strings.HasSuffix(text, "\n") {
+		hotspot.Lines++
+	}
+	hotspot.Complexity = len(decisionPattern.FindAllStringIndex(text, -1)) + 1
+}
+
+// RankHotspots вычисляет риск и сортирует файлы по убыванию риска. Риск -
+// произведение нормированных частоты изменений и сложности (для файлов без
+// ветвлений - размера), усиленное до 1.5 раза количеством авторов.
+func RankHotspots(hotspots []*types.Hotspot) {
+	maxCommits, maxComplexity, maxAuthors := 1, 1, 1
+	for _, hotspot := range hotspots {
+		maxCommits = maxInt(maxCommits, hotspot.Commits)
+		maxComplexity = maxInt(maxComplexity, hotspotComplexity(hotspot))
+		maxAuthors = maxInt(maxAuthors, hotspot.Authors)
+	}
+
+	for _, hotspot := range hotspots {
+		churn := float64(hotspot.Commits) / float64(maxCommits)
+		complexity := float64(hotspotComplexity(hotspot)) / float64(maxComplexity)
+
+		authorFactor := 1.0
+		if maxAuthors > 1 {
+			authorFactor += 0.5 * float64(hotspot.Authors-1) / float64(maxAuthors-1)
+		}
+
+		hotspot.Risk = int(100*churn*complexity*authorFactor/1.5 + 0.5)
+	}
+
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		if hotspots[i].Risk != hotspots[j].Risk {
+			return hotspots[i].Risk > hotspots[j].Risk
+		}
+		if hotspots[i].Commits != hotspots[j].Commits {
+			return hotspots[i].Commits > hotspots[j].Commits
+		}
+		return hotspots[i].File < hotspots[j].File
+	})
+}
+
+// hotspotComplexity возвращает сложность файла, а для файлов без ветвлений - размер
+// в десятках строк, чтобы большие линейные файлы не считались простыми
+func hotspotComplexity(hotspot *types.Hotspot) int {
+	return maxInt(hotspot.Complexity, hotspot.Lines/10)
+}
+
+// maxInt возвращает большее из двух чисел
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
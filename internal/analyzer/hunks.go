@@ -0,0 +1,147 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/chunker"
+	"miniReviewer/internal/git"
+)
+
+// lineRange диапазон строк файла (включительно)
+type lineRange struct {
+	start int
+	end   int
+	name  string // объявление, к которому расширен диапазон
+}
+
+// ExpandFileDiff показывает изменения файла в контексте его новой версии: каждый
+// hunk расширяется до охватывающей функции (или на contextLines строк, если функция
+// не найдена или длиннее maxBlockLines). Добавленные строки отмечаются "+",
+// удаленные показываются без номера с "-", остальные строки - контекст.
+func ExpandFileDiff(file git.FileDiff, content []byte, contextLines, maxBlockLines int) string {
+	lines := strings.Split(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	finder := chunker.NewBlockFinder(file.Path(), content)
+	added := make(map[int]bool)
+	removed := make(map[int][]string)
+
+	var ranges []lineRange
+	for _, hunk := range file.Hunks {
+		var pending []string
+		var changed []int
+
+		for _, line := range hunk.Lines {
+			switch {
+			case line.Kind == '-':
+				pending = append(pending, line.Content)
+			case line.NewLine > 0:
+				if len(pending) > 0 {
+					removed[line.NewLine] = append(removed[line.NewLine], pending...)
+					changed = append(changed, line.NewLine)
+					pending = nil
+				}
+				if line.Kind == '+' {
+					added[line.NewLine] = true
+					changed = append(changed, line.NewLine)
+				}
+			}
+		}
+		if len(pending) > 0 {
+			// Удаленные строки в конце hunk стоят перед следующей строкой новой версии.
+			// Для hunk только из удалений NewStart - строка перед удалением.
+			after := hunk.NewStart + hunk.NewLines
+			if hunk.NewLines == 0 {
+				after = hunk.NewStart + 1
+			}
+			removed[after] = append(removed[after], pending...)
+			changed = append(changed, clampLine(after, len(lines)))
+		}
+
+		// Контекстные строки самого hunk всегда остаются видимыми
+		if hunk.NewLines > 0 {
+			ranges = append(ranges, lineRange{start: hunk.NewStart, end: hunk.NewStart + hunk.NewLines - 1})
+		}
+
+		for _, line := range changed {
+			ranges = append(ranges, expandLine(finder, line, len(lines), contextLines, maxBlockLines))
+		}
+	}
+
+	return renderExpandedDiff(lines, mergeLineRanges(ranges, len(lines)), added, removed)
+}
+
+// expandLine расширяет измененную строку до охватывающего объявления или contextLines строк
+func expandLine(finder *chunker.BlockFinder, line, total, contextLines, maxBlockLines int) lineRange {
+	if block, ok := finder.Enclosing(line); ok && (maxBlockLines <= 0 || block.EndLine-block.StartLine+1 <= maxBlockLines) {
+		return lineRange{start: block.StartLine, end: block.EndLine, name: block.Name}
+	}
+	return lineRange{start: clampLine(line-contextLines, total), end: clampLine(line+contextLines, total)}
+}
+
+// mergeLineRanges сортирует диапазоны и объединяет пересекающиеся и соседние
+func mergeLineRanges(ranges []lineRange, total int) []lineRange {
+	for i := range ranges {
+		ranges[i].start = clampLine(ranges[i].start, total)
+		ranges[i].end = clampLine(ranges[i].end, total)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	var merged []lineRange
+	for _, r := range ranges {
+		if len(merged) > 0 && r.start <= merged[len(merged)-1].end+1 {
+			last := &merged[len(merged)-1]
+			if r.end > last.end {
+				last.end = r.end
+			}
+			if last.name == "" {
+				last.name = r.name
+			} else if r.name != "" && !strings.Contains(last.name, r.name) {
+				last.name += ", " + r.name
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// renderExpandedDiff выводит диапазоны строк с номерами и отметками изменений
+func renderExpandedDiff(lines []string, ranges []lineRange, added map[int]bool, removed map[int][]string) string {
+	var builder strings.Builder
+	for _, r := range ranges {
+		builder.WriteString(fmt.Sprintf("@@ строки %d-%d @@ %s\n", r.start, r.end, r.name))
+		for number := r.start; number <= r.end+1; number++ {
+			for _, content := range removed[number] {
+				builder.WriteString(fmt.Sprintf("     - %s\n", content))
+			}
+			if number > r.end || number > len(lines) {
+				break
+			}
+
+			marker := ' '
+			if added[number] {
+				marker = '+'
+			}
+			builder.WriteString(fmt.Sprintf("%4d %c %s\n", number, marker, lines[number-1]))
+		}
+	}
+	return builder.String()
+}
+
+// clampLine ограничивает номер строки диапазоном файла
+func clampLine(line, total int) int {
+	if line < 1 {
+		return 1
+	}
+	if total > 0 && line > total {
+		return total
+	}
+	return line
+}
@@ -0,0 +1,35 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"miniReviewer/internal/git"
+)
+
+func TestExpandFileDiffDeletionOnlyHunk(t *testing.T) {
+	diff := `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -5 +4,0 @@
+-five
+`
+	content := []byte("one\ntwo\nthree\nfour\nsix\nseven\n")
+
+	files := git.ParseDiff(diff)
+	if len(files) != 1 {
+		t.Fatalf("ParseDiff returned %d files, want 1", len(files))
+	}
+
+	expanded := ExpandFileDiff(files[0], content, 1, 0)
+
+	four := strings.Index(expanded, "   4   four")
+	five := strings.Index(expanded, "     - five")
+	six := strings.Index(expanded, "   5   six")
+	if four < 0 || five < 0 || six < 0 {
+		t.Fatalf("expanded diff is missing lines:\n%s", expanded)
+	}
+	if !(four < five && five < six) {
+		t.Errorf("removed line is not between lines 4 and 5:\n%s", expanded)
+	}
+}
@@ -0,0 +1,255 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// LintTriager объясняет и оценивает замечания внешних линтеров с помощью AI.
+// Файл, строка и текст замечания остаются как у линтера, модель добавляет
+// объяснение, реальную важность, исправление и группу связанных замечаний.
+type LintTriager struct {
+	ollamaClient *ollama.Client
+	contextLines int
+	batchSize    int
+}
+
+// lintTriage оценка одного замечания моделью
+type lintTriage struct {
+	Index         int    `json:"index"`
+	Explanation   string `json:"explanation"`
+	Impact        string `json:"impact"`
+	Type          string `json:"type"`
+	Fix           string `json:"fix"`
+	Group         string `json:"group"`
+	FalsePositive bool   `json:"false_positive"`
+}
+
+// NewLintTriager создает AI-триаж замечаний линтеров с настройками из секции lint
+func NewLintTriager() *LintTriager {
+	batchSize := viper.GetInt("lint.batch_size")
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+
+	return &LintTriager{
+		ollamaClient: ollama.NewClient(),
+		contextLines: viper.GetInt("lint.context_lines"),
+		batchSize:    batchSize,
+	}
+}
+
+// TriageResult дополняет замечания одного файла объяснениями модели. При ошибке
+// модели замечания остаются в исходном виде.
+func (t *LintTriager) TriageResult(result *types.CodeAnalysisResult, verbose bool) {
+	content, err := os.ReadFile(result.File)
+	if err != nil {
+		if verbose {
+			fmt.Printf("   ⚠️  Исходный код недоступен, триаж без контекста: %v\n", err)
+		}
+	}
+	lines := strings.Split(string(content), "\n")
+
+	for start := 0; start < len(result.Issues); start += t.batchSize {
+		end := start + t.batchSize
+		if end > len(result.Issues) {
+			end = len(result.Issues)
+		}
+
+		batch := result.Issues[start:end]
+		if err := t.triageBatch(result.File, lines, batch); err != nil && verbose {
+			fmt.Printf("   ⚠️  %v\n", err)
+		}
+	}
+
+	result.Score = LintScore(result.Issues)
+}
+
+// triageBatch отправляет модели группу замечаний и применяет ответ
+func (t *LintTriager) triageBatch(file string, lines []string, issues []types.Issue) error {
+	response, err := t.ollamaClient.Generate(t.buildPrompt(file, lines, issues))
+	if err != nil {
+		return fmt.Errorf("ошибка AI-триажа %s: %v", file, err)
+	}
+
+	jsonData := extractJSONFromResponse(response)
+	if jsonData == "" {
+		return fmt.Errorf("модель не вернула JSON для %s", file)
+	}
+
+	var parsed struct {
+		Findings []lintTriage `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(jsonData), &parsed); err != nil {
+		return fmt.Errorf("ошибка разбора ответа модели для %s: %v", file, err)
+	}
+
+	for _, triage := range parsed.Findings {
+		if triage.Index < 1 || triage.Index > len(issues) {
+			continue
+		}
+		applyLintTriage(&issues[triage.Index-1], triage)
+	}
+	return nil
+}
+
+// applyLintTriage переносит оценку модели в проблему
+func applyLintTriage(issue *types.Issue, triage lintTriage) {
+	if triage.Explanation != "" {
+		issue.Reasoning = triage.Explanation
+	}
+	if triage.Fix != "" {
+		issue.Suggestion = triage.Fix
+	}
+	if triage.Group != "" {
+		issue.Group = triage.Group
+	}
+	if isValidSeverity(triage.Impact) {
+		issue.Severity = triage.Impact
+	}
+	if isValidIssueType(triage.Type) {
+		issue.Type = triage.Type
+	}
+	if triage.FalsePositive {
+		issue.Severity = "info"
+		issue.Reasoning = strings.TrimSpace("Вероятно, ложное срабатывание. " + issue.Reasoning)
+	}
+}
+
+// buildPrompt строит промпт триажа: замечания с номерами и фрагменты кода вокруг них
+func (t *LintTriager) buildPrompt(file string, lines []string, issues []types.Issue) string {
+	var findings strings.Builder
+	for i, issue := range issues {
+		findings.WriteString(fmt.Sprintf("%d. [%s] строка %d: %s\n", i+1, strings.Join(issue.Categories, ", "), issue.Line, issue.Message))
+		if snippet := lintSnippet(lines, issue.Line, t.contextLines); snippet != "" {
+			findings.WriteString(snippet)
+		}
+		findings.WriteString("\n")
+	}
+
+	return fmt.Sprintf(`Ты - опытный ревьюер кода. Линтеры нашли замечания в файле %s. Их позиции точны.
+Для каждого замечания объясни, в чем проблема, оцени ее реальное влияние (а не формальную важность правила),
+предложи конкретное исправление и объедини связанные замечания в группы с коротким общим названием
+(например, "необработанные ошибки" или "неиспользуемый код"). Отметь ложные срабатывания.
+
+ЗАМЕЧАНИЯ:
+%s
+ОТВЕТЬ ТОЛЬКО В ФОРМАТЕ JSON БЕЗ ДОПОЛНИТЕЛЬНОГО ТЕКСТА:
+{
+  "findings": [
+    {
+      "index": 1,
+      "explanation": "Почему это проблема и к чему она приведет",
+      "impact": "critical|high|medium|low|info",
+      "type": "bug|security|performance|quality|style",
+      "fix": "Конкретное исправление",
+      "group": "Название группы связанных замечаний",
+      "false_positive": false
+    }
+  ]
+}`, file, findings.String())
+}
+
+// lintSnippet возвращает пронумерованные строки кода вокруг замечания
+func lintSnippet(lines []string, line, contextLines int) string {
+	if line <= 0 || line > len(lines) {
+		return ""
+	}
+
+	start := line - 1 - contextLines
+	if start < 0 {
+		start = 0
+	}
+	end := line + contextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	var snippet strings.Builder
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == line-1 {
+			marker = "> "
+		}
+		snippet.WriteString(fmt.Sprintf("   %s%4d | %s\n", marker, i+1, lines[i]))
+	}
+	return snippet.String()
+}
+
+// LintResults группирует проблемы линтеров по файлам
+func LintResults(issues []types.Issue) []*types.CodeAnalysisResult {
+	byFile := make(map[string]*types.CodeAnalysisResult)
+	var files []string
+
+	for _, issue := range issues {
+		file := filepath.Clean(issue.File)
+		result, exists := byFile[file]
+		if !exists {
+			result = &types.CodeAnalysisResult{
+				File:      file,
+				Issues:    []types.Issue{},
+				Timestamp: time.Now(),
+			}
+			if content, err := os.ReadFile(file); err == nil {
+				result.FileHash = ContentHash(content)
+			}
+			byFile[file] = result
+			files = append(files, file)
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+
+	sort.Strings(files)
+	results := make([]*types.CodeAnalysisResult, 0, len(files))
+	for _, file := range files {
+		result := byFile[file]
+		sort.SliceStable(result.Issues, func(i, j int) bool {
+			return result.Issues[i].Line < result.Issues[j].Line
+		})
+		result.Score = LintScore(result.Issues)
+		results = append(results, result)
+	}
+	return results
+}
+
+// LintScore оценка файла по замечаниям линтеров; ложные срабатывания (info) не учитываются
+func LintScore(issues []types.Issue) int {
+	score := 100
+	for _, issue := range issues {
+		if issue.Severity != "info" {
+			score -= 10
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// isValidSeverity проверяет уровень важности
+func isValidSeverity(severity string) bool {
+	switch severity {
+	case "critical", "high", "medium", "low", "info":
+		return true
+	}
+	return false
+}
+
+// isValidIssueType проверяет тип проблемы
+func isValidIssueType(issueType string) bool {
+	switch issueType {
+	case "bug", "security", "performance", "quality", "style":
+		return true
+	}
+	return false
+}
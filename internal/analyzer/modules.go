@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/types"
+)
+
+// ModuleStatistic оценка и количество проблем модуля монорепозитория
+type ModuleStatistic struct {
+	Module     string         `json:"module"` // путь модуля; пустая строка - файлы вне модулей
+	Name       string         `json:"name,omitempty"`
+	Kind       string         `json:"kind,omitempty"`
+	Files      int            `json:"files"`
+	Issues     int            `json:"issues"`
+	AvgScore   int            `json:"avg_score"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// GroupByModule считает файлы, проблемы и среднюю оценку по модулям. Результат
+// относится к модулю из поля Module, а без него - к самому вложенному модулю,
+// в котором находится файл.
+func GroupByModule(results []*types.CodeAnalysisResult, modules []types.WorkspaceModule) []ModuleStatistic {
+	byModule := make(map[string]*ModuleStatistic)
+	totalScore := make(map[string]int)
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		key, name, kind := "", "", ""
+		if module := resultModule(result, modules); module != nil {
+			key, name, kind = module.Path, module.Name, module.Kind
+		}
+
+		stat, exists := byModule[key]
+		if !exists {
+			stat = &ModuleStatistic{Module: key, Name: name, Kind: kind, BySeverity: make(map[string]int)}
+			byModule[key] = stat
+		}
+		stat.Files++
+		totalScore[key] += result.Score
+		for _, issue := range result.Issues {
+			stat.Issues++
+			stat.BySeverity[issue.Severity]++
+		}
+	}
+
+	statistics := make([]ModuleStatistic, 0, len(byModule))
+	for key, stat := range byModule {
+		stat.AvgScore = totalScore[key] / stat.Files
+		statistics = append(statistics, *stat)
+	}
+	sort.Slice(statistics, func(i, j int) bool {
+		return statistics[i].Module < statistics[j].Module
+	})
+	return statistics
+}
+
+// resultModule возвращает модуль, к которому относится результат, или nil
+func resultModule(result *types.CodeAnalysisResult, modules []types.WorkspaceModule) *types.WorkspaceModule {
+	if result.Module == "" {
+		return filesystem.ModuleOf(modules, result.File)
+	}
+	for i := range modules {
+		if modules[i].Path == result.Module {
+			return &modules[i]
+		}
+	}
+	return &types.WorkspaceModule{Name: result.Module, Path: result.Module}
+}
+
+// ModuleLabel возвращает название модуля для вывода
+func ModuleLabel(stat ModuleStatistic) string {
+	if stat.Module == "" {
+		return "Вне модулей"
+	}
+	if stat.Name == "" || stat.Name == stat.Module {
+		return stat.Module
+	}
+	return fmt.Sprintf("%s (%s)", stat.Name, stat.Module)
+}
+
+// PrintModuleStatistics выводит оценки и проблемы по модулям, если в проекте
+// больше одного модуля
+func PrintModuleStatistics(results []*types.CodeAnalysisResult, modules []types.WorkspaceModule) {
+	statistics := GroupByModule(results, modules)
+	if len(statistics) < 2 {
+		return
+	}
+
+	fmt.Printf("\n📦 Проблемы по модулям:\n")
+	for _, stat := range statistics {
+		var parts []string
+		for _, severity := range []string{"critical", "high", "medium", "low", "info"} {
+			if count := stat.BySeverity[severity]; count > 0 {
+				parts = append(parts, fmt.Sprintf("%s: %d", severity, count))
+			}
+		}
+		details := ""
+		if len(parts) > 0 {
+			details = " (" + strings.Join(parts, ", ") + ")"
+		}
+		fmt.Printf("  📦 %s: оценка %d/100, файлов %d, проблем %d%s\n",
+			ModuleLabel(stat), stat.AvgScore, stat.Files, stat.Issues, details)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"miniReviewer/internal/ollama"
 	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
 )
 
 // QualityAnalyzer анализатор качества кода
@@ -16,25 +18,35 @@ type QualityAnalyzer struct {
 
 // NewQualityAnalyzer создает новый анализатор качества
 func NewQualityAnalyzer() *QualityAnalyzer {
+	return NewQualityAnalyzerWithConfig(viper.GetViper())
+}
+
+// NewQualityAnalyzerWithConfig создает анализатор качества с настройками из config
+func NewQualityAnalyzerWithConfig(config *viper.Viper) *QualityAnalyzer {
 	return &QualityAnalyzer{
-		ollamaClient: ollama.NewClient(),
+		ollamaClient: ollama.NewClientWithConfig(config),
 	}
 }
 
 // Analyze анализирует качество кода
 func (a *QualityAnalyzer) Analyze(code string, context string) (*types.CodeAnalysisResult, error) {
-	prompt := a.buildPrompt(code, context)
+	return a.AnalyzeWithRelated(code, context, "")
+}
+
+// AnalyzeWithRelated анализирует код с учетом объявлений из других файлов
+func (a *QualityAnalyzer) AnalyzeWithRelated(code, context, related string) (*types.CodeAnalysisResult, error) {
+	prompt := a.buildPrompt(code, context, related)
 	return a.analyzeWithAI(prompt)
 }
 
 // buildPrompt строит промпт для анализа качества
-func (a *QualityAnalyzer) buildPrompt(code string, context string) string {
+func (a *QualityAnalyzer) buildPrompt(code, context, related string) string {
 	language := detectLanguage(context)
 
 	return fmt.Sprintf(`Ты - эксперт по качеству кода на языке %s. Проанализируй следующий код и найди проблемы качества.
 
 КОНТЕКСТ: %s
-
+%s
 КОД:
 %s
 
@@ -81,7 +93,7 @@ func (a *QualityAnalyzer) buildPrompt(code string, context string) string {
       "reasoning": "Почему это проблема качества"
     }
   ]
-}`, language, context, code, language)
+}`, language, context, buildRelatedSection(related), code, language)
 }
 
 // analyzeWithAI выполняет AI-анализ
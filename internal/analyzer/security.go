@@ -7,6 +7,8 @@ import (
 
 	"miniReviewer/internal/ollama"
 	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
 )
 
 // SecurityAnalyzer анализатор безопасности кода
@@ -16,25 +18,35 @@ type SecurityAnalyzer struct {
 
 // NewSecurityAnalyzer создает новый анализатор безопасности
 func NewSecurityAnalyzer() *SecurityAnalyzer {
+	return NewSecurityAnalyzerWithConfig(viper.GetViper())
+}
+
+// NewSecurityAnalyzerWithConfig создает анализатор безопасности с настройками из config
+func NewSecurityAnalyzerWithConfig(config *viper.Viper) *SecurityAnalyzer {
 	return &SecurityAnalyzer{
-		ollamaClient: ollama.NewClient(),
+		ollamaClient: ollama.NewClientWithConfig(config),
 	}
 }
 
 // Analyze анализирует безопасность кода
 func (a *SecurityAnalyzer) Analyze(code string, context string) (*types.CodeAnalysisResult, error) {
-	prompt := a.buildPrompt(code, context)
+	return a.AnalyzeWithRelated(code, context, "")
+}
+
+// AnalyzeWithRelated анализирует код с учетом объявлений из других файлов
+func (a *SecurityAnalyzer) AnalyzeWithRelated(code, context, related string) (*types.CodeAnalysisResult, error) {
+	prompt := a.buildPrompt(code, context, related)
 	return a.analyzeWithAI(prompt)
 }
 
 // buildPrompt строит промпт для анализа безопасности
-func (a *SecurityAnalyzer) buildPrompt(code string, context string) string {
+func (a *SecurityAnalyzer) buildPrompt(code, context, related string) string {
 	language := detectLanguage(context)
 
 	return fmt.Sprintf(`Ты - эксперт по безопасности кода на языке %s. Проанализируй следующий код на предмет уязвимостей:
 
 КОНТЕКСТ: %s
-
+%s
 КОД:
 %s
 
@@ -66,6 +78,11 @@ func (a *SecurityAnalyzer) buildPrompt(code string, context string) string {
    - Отсутствие шифрования
    - Утечка конфиденциальной информации
 
+КЛАССИФИКАЦИЯ:
+Для каждой уязвимости укажи идентификатор CWE (cwe) и категорию OWASP Top 10 (owasp).
+Используй одну из записей таксономии:
+%s
+
 ВАЖНО:
 - Для каждой уязвимости укажи ТОЧНЫЙ номер строки (line)
 - Оцени важность: low, medium, high, critical
@@ -82,10 +99,12 @@ func (a *SecurityAnalyzer) buildPrompt(code string, context string) string {
       "message": "Описание уязвимости",
       "suggestion": "Как исправить",
       "line": 42,
+      "cwe": "CWE-89",
+      "owasp": "A03:2021",
       "reasoning": "Какой риск представляет уязвимость"
     }
   ]
-}`, language, context, code, language)
+}`, language, context, buildRelatedSection(related), code, buildTaxonomyPrompt())
 }
 
 // analyzeWithAI выполняет AI-анализ
@@ -120,6 +139,9 @@ func (a *SecurityAnalyzer) createFallbackResult(response string) *types.CodeAnal
 	// Анализируем ответ AI и пытаемся извлечь полезную информацию
 	keywords := []string{"проблема", "issue", "ошибка", "error", "уязвимость", "vulnerability", "безопасность", "security"}
 	issues := extractIssuesFromTextBase(response, "security", "AI анализ безопасности завершен", "Требуется ручной анализ безопасности", keywords)
+	for i := range issues {
+		ClassifySecurityIssue(&issues[i])
+	}
 
 	return &types.CodeAnalysisResult{
 		Issues:    issues,
@@ -130,5 +152,12 @@ func (a *SecurityAnalyzer) createFallbackResult(response string) *types.CodeAnal
 
 // validateAndFixResult проверяет и исправляет результат анализа
 func (a *SecurityAnalyzer) validateAndFixResult(result types.CodeAnalysisResult) types.CodeAnalysisResult {
-	return validateAndFixBaseResult(result, "security", "Проблема безопасности кода", "Требуется ручной анализ и исправление")
+	result = validateAndFixBaseResult(result, "security", "Проблема безопасности кода", "Требуется ручной анализ и исправление")
+
+	// Сопоставляем подтипы с таксономией CWE/OWASP
+	for i := range result.Issues {
+		ClassifySecurityIssue(&result.Issues[i])
+	}
+
+	return result
 }
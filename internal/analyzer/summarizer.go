@@ -0,0 +1,367 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/cache"
+	"miniReviewer/internal/codecontext"
+	"miniReviewer/internal/depgraph"
+	"miniReviewer/internal/layering"
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// maxSummaryCodeSize ограничение размера кода файла в промпте описания
+const maxSummaryCodeSize = 12000
+
+// Summarizer строит иерархический обзор проекта: описания файлов сводятся
+// в описания пакетов (map-reduce). Промежуточные описания кэшируются по хешу содержимого.
+type Summarizer struct {
+	ollamaClient *ollama.Client
+	cache        *cache.Cache
+}
+
+// NewSummarizer создает построитель описаний с настройками Ollama из config. Кэш может быть nil.
+func NewSummarizer(summaryCache *cache.Cache, config *viper.Viper) *Summarizer {
+	return &Summarizer{
+		ollamaClient: ollama.NewClientWithConfig(config),
+		cache:        summaryCache,
+	}
+}
+
+// BuildOverview описывает каждый файл, затем каждый каталог по описаниям его файлов.
+// root - корень проекта, module - путь Go модуля (может быть пустым).
+// Файлы, которые не удалось описать, попадают в обзор без назначения.
+func (s *Summarizer) BuildOverview(root, module string, files []string, verbose bool) *types.ModuleOverview {
+	filesByPackage := make(map[string][]types.FileSummary)
+
+	for i, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			rel = file
+		}
+		rel = filepath.ToSlash(rel)
+
+		if verbose {
+			fmt.Printf("📝 [%d/%d] Описываю файл: %s\n", i+1, len(files), rel)
+		}
+
+		summary, err := s.SummarizeFile(file, rel, module)
+		if err != nil {
+			fmt.Printf("   ⚠️  Не удалось описать %s: %v\n", rel, err)
+		}
+
+		dir := path.Dir(rel)
+		filesByPackage[dir] = append(filesByPackage[dir], summary)
+	}
+
+	packages := make([]string, 0, len(filesByPackage))
+	for dir := range filesByPackage {
+		packages = append(packages, dir)
+	}
+	sort.Strings(packages)
+
+	overview := &types.ModuleOverview{Root: root}
+	for _, dir := range packages {
+		if verbose {
+			fmt.Printf("📦 Описываю пакет: %s\n", dir)
+		}
+
+		summary, err := s.SummarizePackage(dir, filesByPackage[dir])
+		if err != nil {
+			fmt.Printf("   ⚠️  Не удалось описать пакет %s: %v\n", dir, err)
+		}
+		overview.Packages = append(overview.Packages, summary)
+	}
+
+	linkPackageDependencies(overview)
+
+	if s.cache != nil {
+		if err := s.cache.Save(); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+	}
+
+	return overview
+}
+
+// SummarizeFile описывает назначение файла. Экспортируемый API Go файлов и импорты
+// определяются статически, модель отвечает только за назначение (и API для других языков).
+func (s *Summarizer) SummarizeFile(file, rel, module string) (types.FileSummary, error) {
+	summary := types.FileSummary{File: rel}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, ref := range layering.ExtractImports(rel, content, module) {
+		summary.Dependencies = depgraph.AppendUnique(summary.Dependencies, ref.Raw)
+	}
+	if strings.HasSuffix(rel, ".go") {
+		summary.Exports = goExports(content)
+	}
+
+	key := fmt.Sprintf("file:%s:%s:%s", s.ollamaClient.Model(), rel, ContentHash(content))
+	var cached types.FileSummary
+	if s.cache != nil && s.cache.Get(key, &cached) {
+		return cached, nil
+	}
+
+	code := string(content)
+	if len(code) > maxSummaryCodeSize {
+		code = strings.ToValidUTF8(code[:maxSummaryCodeSize], "") + "\n... (файл обрезан)"
+	}
+
+	response, err := s.ollamaClient.Generate(buildFileSummaryPrompt(rel, code, summary.Exports))
+	if err != nil {
+		return summary, fmt.Errorf("ошибка AI-описания файла: %v", err)
+	}
+
+	var parsed struct {
+		Purpose string   `json:"purpose"`
+		Exports []string `json:"exports"`
+	}
+	if jsonData := extractJSONFromResponse(response); jsonData != "" && json.Unmarshal([]byte(jsonData), &parsed) == nil {
+		summary.Purpose = strings.TrimSpace(parsed.Purpose)
+		if len(summary.Exports) == 0 {
+			summary.Exports = parsed.Exports
+		}
+	} else {
+		summary.Purpose = firstSentence(response)
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Set(key, summary); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// SummarizePackage описывает пакет по описаниям его файлов
+func (s *Summarizer) SummarizePackage(dir string, files []types.FileSummary) (types.PackageSummary, error) {
+	summary := types.PackageSummary{Package: dir, Files: files}
+
+	rendered := renderFileSummaries(files)
+	key := fmt.Sprintf("package:%s:%s:%s", s.ollamaClient.Model(), dir, ContentHash([]byte(rendered)))
+
+	var cached struct {
+		Purpose          string   `json:"purpose"`
+		Responsibilities []string `json:"responsibilities"`
+	}
+	if s.cache != nil && s.cache.Get(key, &cached) {
+		summary.Purpose = cached.Purpose
+		summary.Responsibilities = cached.Responsibilities
+		return summary, nil
+	}
+
+	response, err := s.ollamaClient.Generate(buildPackageSummaryPrompt(dir, rendered))
+	if err != nil {
+		return summary, fmt.Errorf("ошибка AI-описания пакета: %v", err)
+	}
+
+	if jsonData := extractJSONFromResponse(response); jsonData != "" && json.Unmarshal([]byte(jsonData), &cached) == nil {
+		cached.Purpose = strings.TrimSpace(cached.Purpose)
+	} else {
+		cached.Purpose = firstSentence(response)
+		cached.Responsibilities = nil
+	}
+
+	summary.Purpose = cached.Purpose
+	summary.Responsibilities = cached.Responsibilities
+
+	if s.cache != nil {
+		if err := s.cache.Set(key, cached); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// buildFileSummaryPrompt строит промпт описания файла
+func buildFileSummaryPrompt(file, code string, exports []string) string {
+	exportsHint := "Перечисли экспортируемый API (публичные функции, классы, типы) в поле exports."
+	if len(exports) > 0 {
+		exportsHint = "Экспортируемый API уже определен статически, поле exports можно не заполнять."
+	}
+
+	return fmt.Sprintf(`Ты - опытный разработчик. Кратко опиши файл %s для обзора архитектуры проекта.
+
+КОД:
+%s
+
+Опиши назначение файла одним-двумя предложениями: какую задачу он решает и какую роль играет в проекте.
+%s
+
+ОТВЕТЬ ТОЛЬКО В ФОРМАТЕ JSON БЕЗ ДОПОЛНИТЕЛЬНОГО ТЕКСТА:
+{
+  "purpose": "Назначение файла",
+  "exports": ["PublicFunction", "PublicType"]
+}`, file, code, exportsHint)
+}
+
+// buildPackageSummaryPrompt строит промпт описания пакета по описаниям файлов
+func buildPackageSummaryPrompt(dir, files string) string {
+	return fmt.Sprintf(`Ты - эксперт по архитектуре кода. По описаниям файлов кратко опиши пакет (каталог) %s.
+
+ФАЙЛЫ ПАКЕТА:
+%s
+
+Опиши назначение пакета одним-двумя предложениями и перечисли его основные обязанности (не более 5).
+
+ОТВЕТЬ ТОЛЬКО В ФОРМАТЕ JSON БЕЗ ДОПОЛНИТЕЛЬНОГО ТЕКСТА:
+{
+  "purpose": "Назначение пакета",
+  "responsibilities": ["Обязанность 1", "Обязанность 2"]
+}`, dir, files)
+}
+
+// renderFileSummaries формирует текст описаний файлов для промпта пакета
+func renderFileSummaries(files []types.FileSummary) string {
+	var builder strings.Builder
+	for _, file := range files {
+		builder.WriteString(fmt.Sprintf("- %s: %s\n", file.File, file.Purpose))
+		if len(file.Exports) > 0 {
+			builder.WriteString(fmt.Sprintf("  API: %s\n", strings.Join(file.Exports, ", ")))
+		}
+		if len(file.Dependencies) > 0 {
+			builder.WriteString(fmt.Sprintf("  Импорты: %s\n", strings.Join(file.Dependencies, ", ")))
+		}
+	}
+	return builder.String()
+}
+
+// RenderOverview формирует текст обзора пакетов для финального архитектурного анализа
+func RenderOverview(overview *types.ModuleOverview) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Обзор проекта (%d пакетов):\n", len(overview.Packages)))
+
+	for _, pkg := range overview.Packages {
+		builder.WriteString(fmt.Sprintf("\nПакет %s (%d файлов): %s\n", pkg.Package, len(pkg.Files), pkg.Purpose))
+		for _, responsibility := range pkg.Responsibilities {
+			builder.WriteString(fmt.Sprintf("  - %s\n", responsibility))
+		}
+		if len(pkg.Dependencies) > 0 {
+			builder.WriteString(fmt.Sprintf("  Зависит от: %s\n", strings.Join(pkg.Dependencies, ", ")))
+		}
+		for _, file := range pkg.Files {
+			builder.WriteString(fmt.Sprintf("  %s: %s\n", path.Base(file.File), file.Purpose))
+		}
+	}
+
+	return builder.String()
+}
+
+// linkPackageDependencies вычисляет зависимости между пакетами обзора по импортам файлов
+func linkPackageDependencies(overview *types.ModuleOverview) {
+	known := make(map[string]bool)
+	for _, pkg := range overview.Packages {
+		known[pkg.Package] = true
+	}
+
+	for i := range overview.Packages {
+		pkg := &overview.Packages[i]
+		for _, file := range pkg.Files {
+			for _, dependency := range file.Dependencies {
+				target := resolvePackage(pkg.Package, dependency, known)
+				if target != "" && target != pkg.Package {
+					pkg.Dependencies = depgraph.AppendUnique(pkg.Dependencies, target)
+				}
+			}
+		}
+		sort.Strings(pkg.Dependencies)
+	}
+}
+
+// resolvePackage сопоставляет импорт с каталогом проекта: Go пути сопоставляются
+// по суффиксу, относительные импорты JS/Python - от каталога пакета
+func resolvePackage(dir, dependency string, known map[string]bool) string {
+	candidates := []string{dependency}
+	if strings.HasPrefix(dependency, "./") || strings.HasPrefix(dependency, "../") {
+		target := path.Join(dir, dependency)
+		candidates = []string{target, path.Dir(target)}
+	} else if strings.Contains(dependency, ".") && !strings.Contains(dependency, "/") {
+		candidates = []string{strings.ReplaceAll(dependency, ".", "/")}
+	}
+
+	for _, candidate := range candidates {
+		if known[candidate] {
+			return candidate
+		}
+		for pkg := range known {
+			if pkg != "." && strings.HasSuffix(candidate, "/"+pkg) {
+				return pkg
+			}
+		}
+	}
+	return ""
+}
+
+// goExports возвращает экспортируемые объявления Go файла
+func goExports(content []byte) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+
+	var exports []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			if receiver := codecontext.ReceiverName(d); receiver != "" {
+				exports = append(exports, receiver+"."+d.Name.Name)
+			} else {
+				exports = append(exports, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Name.IsExported() {
+						exports = append(exports, s.Name.Name)
+					}
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						if name.IsExported() {
+							exports = append(exports, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return exports
+}
+
+// firstSentence возвращает первую непустую строку ответа как запасное описание
+func firstSentence(response string) string {
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(strings.Trim(line, "`#*- "))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > 300 {
+			line = string(runes[:300]) + "..."
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,261 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+// CWEEntry запись встроенной таксономии уязвимостей
+type CWEEntry struct {
+	ID       string   // идентификатор CWE, например "CWE-89"
+	Name     string   // название слабости
+	OWASP    string   // категория OWASP Top 10 (2021)
+	Aliases  []string // подтипы, которые модель может вернуть в поле type
+	Keywords []string // ключевые слова для классификации по тексту сообщения
+}
+
+// OWASPCategories категории OWASP Top 10 (2021)
+var OWASPCategories = map[string]string{
+	"A01:2021": "Broken Access Control",
+	"A02:2021": "Cryptographic Failures",
+	"A03:2021": "Injection",
+	"A04:2021": "Insecure Design",
+	"A05:2021": "Security Misconfiguration",
+	"A06:2021": "Vulnerable and Outdated Components",
+	"A07:2021": "Identification and Authentication Failures",
+	"A08:2021": "Software and Data Integrity Failures",
+	"A09:2021": "Security Logging and Monitoring Failures",
+	"A10:2021": "Server-Side Request Forgery",
+}
+
+// securityTaxonomy встроенная таксономия: CWE и соответствующие категории OWASP.
+// Порядок важен для классификации по ключевым словам: более конкретные записи идут раньше.
+var securityTaxonomy = []CWEEntry{
+	{ID: "CWE-943", Name: "NoSQL Injection", OWASP: "A03:2021",
+		Aliases: []string{"nosql_injection", "nosqli"}, Keywords: []string{"nosql injection", "nosql-инъекц", "nosql инъекц"}},
+	{ID: "CWE-89", Name: "SQL Injection", OWASP: "A03:2021",
+		Aliases: []string{"sql_injection", "sqli", "sql"}, Keywords: []string{"sql injection", "sql-инъекц", "sql инъекц", "sqli"}},
+	{ID: "CWE-90", Name: "LDAP Injection", OWASP: "A03:2021",
+		Aliases: []string{"ldap_injection"}, Keywords: []string{"ldap injection", "ldap-инъекц", "ldap инъекц"}},
+	{ID: "CWE-78", Name: "OS Command Injection", OWASP: "A03:2021",
+		Aliases:  []string{"command_injection", "os_command_injection", "shell_injection", "rce"},
+		Keywords: []string{"command injection", "командн", "os.exec", "exec.command", "shell_exec", "system(", "subprocess"}},
+	{ID: "CWE-94", Name: "Code Injection", OWASP: "A03:2021",
+		Aliases: []string{"code_injection", "eval", "code_execution"}, Keywords: []string{"eval(", "eval()", "динамическ", "code injection"}},
+	{ID: "CWE-79", Name: "Cross-site Scripting", OWASP: "A03:2021",
+		Aliases: []string{"xss", "cross_site_scripting"}, Keywords: []string{"xss", "innerhtml", "document.write", "экранир"}},
+	{ID: "CWE-22", Name: "Path Traversal", OWASP: "A01:2021",
+		Aliases: []string{"path_traversal", "directory_traversal", "lfi"}, Keywords: []string{"path traversal", "../", "traversal"}},
+	{ID: "CWE-352", Name: "Cross-Site Request Forgery", OWASP: "A01:2021",
+		Aliases: []string{"csrf", "xsrf"}, Keywords: []string{"csrf"}},
+	{ID: "CWE-601", Name: "Open Redirect", OWASP: "A01:2021",
+		Aliases: []string{"open_redirect"}, Keywords: []string{"redirect", "перенаправлен"}},
+	{ID: "CWE-862", Name: "Missing Authorization", OWASP: "A01:2021",
+		Aliases: []string{"authorization", "access_control", "missing_authorization"}, Keywords: []string{"authorization", "авторизац", "прав доступа", "проверки прав"}},
+	{ID: "CWE-732", Name: "Incorrect Permission Assignment", OWASP: "A01:2021",
+		Aliases: []string{"permissions", "file_permissions"}, Keywords: []string{"chmod", "0777", "разрешени"}},
+	{ID: "CWE-200", Name: "Exposure of Sensitive Information", OWASP: "A01:2021",
+		Aliases: []string{"information_disclosure", "info_leak", "data_exposure", "sensitive_data"}, Keywords: []string{"утечк", "leak", "disclosure", "конфиденциальн"}},
+	{ID: "CWE-521", Name: "Weak Password Requirements", OWASP: "A07:2021",
+		Aliases: []string{"weak_password"}, Keywords: []string{"слабый пароль", "слабые пароли", "weak password"}},
+	{ID: "CWE-798", Name: "Hard-coded Credentials", OWASP: "A07:2021",
+		Aliases: []string{"hardcoded_credentials", "hardcoded_credential", "hardcoded_secret", "hardcoded_password", "hardcoded_token", "secrets", "secret", "credentials"},
+		Keywords: []string{"hardcoded password", "hardcoded secret", "hardcoded credential", "hardcoded token", "hardcoded api key",
+			"hard-coded password", "hard-coded secret", "hard-coded credential", "hard-coded token", "hard-coded api key",
+			"захардкоженный пароль", "захардкоженный токен", "захардкоженный ключ", "захардкоженный секрет", "захардкоженные учетные",
+			"пароль в коде", "токен в коде", "секрет в коде", "ключ api в коде"}},
+	{ID: "CWE-287", Name: "Improper Authentication", OWASP: "A07:2021",
+		Aliases: []string{"authentication", "auth", "broken_authentication"}, Keywords: []string{"authentication", "аутентификац"}},
+	{ID: "CWE-613", Name: "Insufficient Session Expiration", OWASP: "A07:2021",
+		Aliases: []string{"session", "session_management"}, Keywords: []string{"session", "сесси"}},
+	{ID: "CWE-327", Name: "Use of a Broken or Risky Cryptographic Algorithm", OWASP: "A02:2021",
+		Aliases: []string{"weak_crypto", "crypto", "weak_cryptography", "cryptography"}, Keywords: []string{"md5", "sha1", "3des", "rc4", "шифрован", "crypto"}},
+	{ID: "CWE-319", Name: "Cleartext Transmission of Sensitive Information", OWASP: "A02:2021",
+		Aliases: []string{"cleartext_transmission", "insecure_transport"}, Keywords: []string{"http://", "tls", "cleartext", "открытом виде"}},
+	{ID: "CWE-502", Name: "Deserialization of Untrusted Data", OWASP: "A08:2021",
+		Aliases: []string{"deserialization", "insecure_deserialization"}, Keywords: []string{"deserializ", "десериализ", "pickle", "unserialize"}},
+	{ID: "CWE-611", Name: "XML External Entity Reference", OWASP: "A05:2021",
+		Aliases: []string{"xxe"}, Keywords: []string{"xxe", "external entit"}},
+	{ID: "CWE-16", Name: "Configuration", OWASP: "A05:2021",
+		Aliases: []string{"misconfiguration", "configuration", "security_misconfiguration"}, Keywords: []string{"misconfigur", "конфигурац", "debug"}},
+	{ID: "CWE-1104", Name: "Use of Unmaintained Third Party Components", OWASP: "A06:2021",
+		Aliases: []string{"dependency", "vulnerable_dependency", "outdated_dependency"}, Keywords: []string{"зависимост", "dependency", "outdated"}},
+	{ID: "CWE-532", Name: "Insertion of Sensitive Information into Log File", OWASP: "A09:2021",
+		Aliases: []string{"logging", "log_injection", "sensitive_logging"}, Keywords: []string{"логир", "в лог", "logging", "log file"}},
+	{ID: "CWE-918", Name: "Server-Side Request Forgery", OWASP: "A10:2021",
+		Aliases: []string{"ssrf"}, Keywords: []string{"ssrf", "request forgery"}},
+	{ID: "CWE-20", Name: "Improper Input Validation", OWASP: "A03:2021",
+		Aliases: []string{"input_validation", "validation", "injection"}, Keywords: []string{"валидац", "validation", "пользовательский ввод", "user input", "injection", "инъекц"}},
+}
+
+// genericSecurityTypes общие типы, которые считаются проблемами безопасности без уточнения CWE
+var genericSecurityTypes = map[string]bool{
+	"security":      true,
+	"vulnerability": true,
+}
+
+var cwePattern = regexp.MustCompile(`(?i)^\s*(?:cwe)?[\s:_-]*(\d+)\s*$`)
+var owaspPattern = regexp.MustCompile(`(?i)^\s*(A\d{1,2})(?::?\s*(\d{4}))?`)
+
+// LookupCWE возвращает запись таксономии по идентификатору CWE
+func LookupCWE(id string) (CWEEntry, bool) {
+	normalized := NormalizeCWE(id)
+	for _, entry := range securityTaxonomy {
+		if entry.ID == normalized {
+			return entry, true
+		}
+	}
+	return CWEEntry{}, false
+}
+
+// NormalizeCWE приводит идентификатор к виду "CWE-<номер>"
+func NormalizeCWE(id string) string {
+	match := cwePattern.FindStringSubmatch(id)
+	if match == nil {
+		return ""
+	}
+	return "CWE-" + strings.TrimLeft(match[1], "0")
+}
+
+// NormalizeOWASP приводит категорию OWASP к виду "A03:2021"
+func NormalizeOWASP(category string) string {
+	match := owaspPattern.FindStringSubmatch(category)
+	if match == nil {
+		return ""
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(match[1]), "A"))
+	if err != nil {
+		return ""
+	}
+	year := match[2]
+	if year == "" {
+		year = "2021"
+	}
+	return fmt.Sprintf("A%02d:%s", number, year)
+}
+
+// normalizeSubtype приводит подтип к виду snake_case
+func normalizeSubtype(issueType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(issueType))
+	normalized = strings.NewReplacer("-", "_", " ", "_").Replace(normalized)
+	return normalized
+}
+
+// ClassifySecurityIssue сопоставляет проблему с таксономией: заполняет CWE и OWASP
+// и нормализует тип в "security". Возвращает false, если проблема не относится
+// к безопасности.
+func ClassifySecurityIssue(issue *types.Issue) bool {
+	subtype := normalizeSubtype(issue.Type)
+
+	entry, found := LookupCWE(issue.CWE)
+	if !found {
+		entry, found = lookupAlias(subtype)
+	}
+	if !found && (genericSecurityTypes[subtype] || subtype == "") {
+		entry, found = lookupKeywords(issue.Message+" "+issue.Reasoning, NormalizeOWASP(issue.OWASP))
+	}
+
+	switch {
+	case found:
+		issue.CWE = entry.ID
+		issue.OWASP = entry.OWASP
+	case NormalizeCWE(issue.CWE) != "":
+		// CWE вне встроенной таксономии сохраняем как есть
+		issue.CWE = NormalizeCWE(issue.CWE)
+		issue.OWASP = NormalizeOWASP(issue.OWASP)
+	case genericSecurityTypes[subtype]:
+		issue.CWE = ""
+		issue.OWASP = NormalizeOWASP(issue.OWASP)
+	default:
+		return false
+	}
+
+	issue.Type = "security"
+	return true
+}
+
+// lookupAlias ищет запись таксономии по подтипу
+func lookupAlias(subtype string) (CWEEntry, bool) {
+	for _, entry := range securityTaxonomy {
+		for _, alias := range entry.Aliases {
+			if subtype == alias {
+				return entry, true
+			}
+		}
+	}
+	return CWEEntry{}, false
+}
+
+// lookupKeywords ищет запись таксономии по ключевым словам в тексте. Если модель
+// указала категорию OWASP, рассматриваются только записи этой категории: явная
+// категория важнее совпадения слов в сообщении.
+func lookupKeywords(text, owasp string) (CWEEntry, bool) {
+	lower := strings.ToLower(text)
+	for _, entry := range securityTaxonomy {
+		if owasp != "" && entry.OWASP != owasp {
+			continue
+		}
+		for _, keyword := range entry.Keywords {
+			if strings.Contains(lower, keyword) {
+				return entry, true
+			}
+		}
+	}
+	return CWEEntry{}, false
+}
+
+// CWEName возвращает название CWE из таксономии
+func CWEName(id string) string {
+	if entry, ok := LookupCWE(id); ok {
+		return entry.Name
+	}
+	return ""
+}
+
+// buildTaxonomyPrompt формирует список допустимых CWE для промпта
+func buildTaxonomyPrompt() string {
+	var lines []string
+	for _, entry := range securityTaxonomy {
+		lines = append(lines, fmt.Sprintf("   - %s %s (OWASP %s)", entry.ID, entry.Name, entry.OWASP))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CWEStatistic количество проблем по одной CWE
+type CWEStatistic struct {
+	CWE   string
+	Name  string
+	OWASP string
+	Count int
+}
+
+// GroupByCWE считает проблемы по CWE; проблемы без CWE попадают в группу с пустым ID.
+// Группы отсортированы по убыванию количества.
+func GroupByCWE(issues []types.Issue) []CWEStatistic {
+	counts := make(map[string]*CWEStatistic)
+	for _, issue := range issues {
+		stat, exists := counts[issue.CWE]
+		if !exists {
+			stat = &CWEStatistic{CWE: issue.CWE, Name: CWEName(issue.CWE), OWASP: issue.OWASP}
+			counts[issue.CWE] = stat
+		}
+		stat.Count++
+	}
+
+	var stats []CWEStatistic
+	for _, stat := range counts {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].CWE < stats[j].CWE
+	})
+	return stats
+}
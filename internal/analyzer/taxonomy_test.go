@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"testing"
+
+	"miniReviewer/internal/types"
+)
+
+func TestClassifySecurityIssueKeywords(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"token bucket rate limiter allows bursts", ""},
+		{"CSRF token not validated", "CWE-352"},
+		{"password hashing uses MD5", "CWE-327"},
+		{"SQL query built with fmt.Sprintf is vulnerable to SQL injection", "CWE-89"},
+		{"hardcoded password in config loader", "CWE-798"},
+		{"Захардкоженный токен доступа", "CWE-798"},
+		{"sql.Open result is never closed", ""},
+	}
+
+	for _, tt := range tests {
+		issue := types.Issue{Type: "security", Message: tt.message}
+		if !ClassifySecurityIssue(&issue) {
+			t.Fatalf("%q: not classified as security issue", tt.message)
+		}
+		if issue.CWE != tt.want {
+			t.Errorf("%q: got CWE %q, want %q", tt.message, issue.CWE, tt.want)
+		}
+	}
+}
+
+func TestClassifySecurityIssueAliasWins(t *testing.T) {
+	issue := types.Issue{Type: "csrf", Message: "hardcoded token in form"}
+	if !ClassifySecurityIssue(&issue) {
+		t.Fatal("not classified as security issue")
+	}
+	if issue.CWE != "CWE-352" || issue.OWASP != "A01:2021" {
+		t.Errorf("got %s %s, want CWE-352 A01:2021", issue.CWE, issue.OWASP)
+	}
+	if issue.Type != "security" {
+		t.Errorf("got type %q, want security", issue.Type)
+	}
+}
+
+func TestClassifySecurityIssueOWASPCategory(t *testing.T) {
+	issue := types.Issue{Type: "security", OWASP: "A02", Message: "hardcoded password is hashed with MD5"}
+	ClassifySecurityIssue(&issue)
+	if issue.CWE != "CWE-327" || issue.OWASP != "A02:2021" {
+		t.Errorf("got %s %s, want CWE-327 A02:2021", issue.CWE, issue.OWASP)
+	}
+
+	issue = types.Issue{Type: "security", OWASP: "A09:2021", Message: "hardcoded password"}
+	ClassifySecurityIssue(&issue)
+	if issue.CWE != "" || issue.OWASP != "A09:2021" {
+		t.Errorf("got %q %q, want no CWE and A09:2021", issue.CWE, issue.OWASP)
+	}
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry запись кэша с временем сохранения
+type Entry struct {
+	Value     json.RawMessage `json:"value"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Cache файловый кэш промежуточных результатов в формате JSON.
+// Ключи должны включать хеш исходных данных, поэтому устаревшие записи
+// просто перестают запрашиваться; TTL ограничивает срок жизни записей.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	enabled bool
+	entries map[string]Entry
+	dirty   bool
+	mu      sync.Mutex
+}
+
+// Open загружает кэш из файла. Отсутствующий файл не считается ошибкой.
+// Если enabled равен false, кэш ничего не читает и не сохраняет.
+func Open(path string, ttl time.Duration, enabled bool) (*Cache, error) {
+	c := &Cache{
+		path:    path,
+		ttl:     ttl,
+		enabled: enabled,
+		entries: make(map[string]Entry),
+	}
+
+	if !enabled {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("ошибка чтения кэша: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		// Поврежденный кэш не должен мешать работе - начинаем с пустого
+		c.entries = make(map[string]Entry)
+	}
+
+	return c, nil
+}
+
+// Get читает значение по ключу в target. Возвращает false, если записи нет или она устарела.
+func (c *Cache) Get(key string, target interface{}) bool {
+	if !c.enabled {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, exists := c.entries[key]
+	c.mu.Unlock()
+
+	if !exists {
+		return false
+	}
+	if c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl {
+		return false
+	}
+
+	return json.Unmarshal(entry.Value, target) == nil
+}
+
+// Set сохраняет значение по ключу
+func (c *Cache) Set(key string, value interface{}) error {
+	if !c.enabled {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("ошибка маршалинга записи кэша: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = Entry{Value: data, CreatedAt: time.Now()}
+	c.dirty = true
+	return nil
+}
+
+// Save записывает кэш на диск, удаляя устаревшие записи
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled || !c.dirty {
+		return nil
+	}
+
+	for key, entry := range c.entries {
+		if c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ошибка маршалинга кэша: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("ошибка создания каталога кэша: %v", err)
+	}
+
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("ошибка записи кэша: %v", err)
+	}
+
+	c.dirty = false
+	return nil
+}
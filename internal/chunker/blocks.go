@@ -0,0 +1,137 @@
+package chunker
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// definitionPattern строки, начинающие функцию, метод или класс в языках без разбора AST
+var definitionPattern = regexp.MustCompile(`^\s*(?:` +
+	`(?:export\s+)?(?:default\s+)?(?:async\s+)?(?:def|class|function|func|fn|impl|module|sub|interface|struct|enum)\b` +
+	`|(?:public|private|protected|internal|static|override|virtual|abstract|final)\b` +
+	`|(?:export\s+)?(?:const|let|var)\s+\w+\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)` +
+	`|(?:async\s+)?\w+\s*\([^)]*\)\s*\{\s*$` +
+	`)`)
+
+// Block объявление, охватывающее строку файла
+type Block struct {
+	Name      string // имя объявления, если известно
+	StartLine int
+	EndLine   int
+}
+
+// BlockFinder ищет объявления (функции, методы, классы), охватывающие строки файла.
+// Для Go используется go/ast, для остальных языков - эвристика по отступам.
+type BlockFinder struct {
+	lines  []string
+	chunks []Chunk
+	isGo   bool
+}
+
+// NewBlockFinder создает поиск объявлений для содержимого файла
+func NewBlockFinder(file string, content []byte) *BlockFinder {
+	finder := &BlockFinder{lines: strings.Split(string(content), "\n")}
+
+	if filepath.Ext(file) == ".go" {
+		// Если файл не разбирается (например, изменения сломали синтаксис), используем отступы
+		if chunks, err := SplitGoFile(file, content); err == nil {
+			finder.chunks = chunks
+			finder.isGo = true
+		}
+	}
+
+	return finder
+}
+
+// Enclosing возвращает объявление, в котором находится строка
+func (b *BlockFinder) Enclosing(line int) (Block, bool) {
+	if line <= 0 || line > len(b.lines) {
+		return Block{}, false
+	}
+
+	if b.isGo {
+		for _, chunk := range b.chunks {
+			if line >= chunk.StartLine && line <= chunk.EndLine {
+				return Block{Name: chunk.Name, StartLine: chunk.StartLine, EndLine: chunk.EndLine}, true
+			}
+		}
+		return Block{}, false
+	}
+
+	return b.indentBlock(line)
+}
+
+// indentBlock находит объявление по отступам: поднимается к ближайшей строке-определению
+// с меньшим отступом и спускается до первой строки с таким же или меньшим отступом
+func (b *BlockFinder) indentBlock(line int) (Block, bool) {
+	index := line - 1
+	for index < len(b.lines) && strings.TrimSpace(b.lines[index]) == "" {
+		index++
+	}
+	if index >= len(b.lines) {
+		return Block{}, false
+	}
+
+	start := -1
+	if definitionPattern.MatchString(b.lines[index]) {
+		start = index
+	} else {
+		current := indentation(b.lines[index])
+		for i := index - 1; i >= 0; i-- {
+			if strings.TrimSpace(b.lines[i]) == "" {
+				continue
+			}
+			level := indentation(b.lines[i])
+			if level >= current {
+				continue
+			}
+			if definitionPattern.MatchString(b.lines[i]) {
+				start = i
+				break
+			}
+			current = level
+			if current == 0 {
+				break
+			}
+		}
+	}
+	if start < 0 {
+		return Block{}, false
+	}
+
+	level := indentation(b.lines[start])
+	end := start
+	for i := start + 1; i < len(b.lines); i++ {
+		trimmed := strings.TrimSpace(b.lines[i])
+		if trimmed == "" {
+			continue
+		}
+		if indentation(b.lines[i]) <= level {
+			// Закрывающая скобка или end относятся к объявлению
+			if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, ")") || strings.HasPrefix(trimmed, "]") || trimmed == "end" {
+				end = i
+			}
+			break
+		}
+		end = i
+	}
+
+	return Block{Name: strings.TrimSpace(b.lines[start]), StartLine: start + 1, EndLine: end + 1}, true
+}
+
+// indentation возвращает ширину отступа строки (табуляция считается за 4 пробела)
+func indentation(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
@@ -0,0 +1,112 @@
+package chunker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Chunk фрагмент файла, соответствующий верхнеуровневому объявлению
+type Chunk struct {
+	Name      string // имя функции или типа, для методов "(T).Name"
+	Kind      string // func, method, type, var, const
+	Receiver  string // тип-получатель для методов
+	Doc       string // doc-комментарий объявления
+	Code      string // исходный код объявления без doc-комментария
+	StartLine int    // первая строка объявления в файле (с 1)
+	EndLine   int    // последняя строка объявления в файле
+}
+
+// SplitGoFile разбивает Go файл на верхнеуровневые объявления.
+// Импорты пропускаются, остальные объявления возвращаются в порядке следования.
+func SplitGoFile(file string, content []byte) ([]Chunk, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора %s: %v", file, err)
+	}
+
+	var chunks []Chunk
+	for _, decl := range f.Decls {
+		chunk := Chunk{
+			StartLine: fset.Position(decl.Pos()).Line,
+			EndLine:   fset.Position(decl.End()).Line,
+			Code:      string(content[fset.Position(decl.Pos()).Offset:fset.Position(decl.End()).Offset]),
+		}
+
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			chunk.Kind = "func"
+			chunk.Name = d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				chunk.Kind = "method"
+				chunk.Receiver = exprString(content, fset, d.Recv.List[0].Type)
+				chunk.Name = fmt.Sprintf("(%s).%s", chunk.Receiver, d.Name.Name)
+			}
+			if d.Doc != nil {
+				chunk.Doc = strings.TrimSpace(d.Doc.Text())
+			}
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			chunk.Kind = d.Tok.String()
+			chunk.Name = genDeclName(d)
+			if d.Doc != nil {
+				chunk.Doc = strings.TrimSpace(d.Doc.Text())
+			}
+		default:
+			continue
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// LineCount возвращает количество строк во фрагменте
+func (c Chunk) LineCount() int {
+	return c.EndLine - c.StartLine + 1
+}
+
+// ToAbsoluteLine переводит номер строки внутри фрагмента в номер строки файла.
+// Номера, уже попадающие в диапазон фрагмента, считаются абсолютными.
+func (c Chunk) ToAbsoluteLine(line int) int {
+	switch {
+	case line <= 0:
+		return 0
+	case line <= c.LineCount():
+		return c.StartLine + line - 1
+	case line >= c.StartLine && line <= c.EndLine:
+		return line
+	default:
+		return c.StartLine
+	}
+}
+
+// genDeclName возвращает имя для группы объявлений
+func genDeclName(d *ast.GenDecl) string {
+	var names []string
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, s.Name.Name)
+		case *ast.ValueSpec:
+			for _, name := range s.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	if len(names) > 3 {
+		names = append(names[:3], "...")
+	}
+	return d.Tok.String() + " " + strings.Join(names, ", ")
+}
+
+// exprString возвращает исходный текст выражения
+func exprString(content []byte, fset *token.FileSet, expr ast.Expr) string {
+	return string(content[fset.Position(expr.Pos()).Offset:fset.Position(expr.End()).Offset])
+}
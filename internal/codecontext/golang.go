@@ -0,0 +1,305 @@
+package codecontext
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"miniReviewer/internal/depgraph"
+)
+
+func init() {
+	Register(&GoProvider{})
+}
+
+// GoProvider собирает контекст для Go файлов: объявления из файлов того же
+// пакета и из локальных пакетов модуля, на которые ссылается файл
+type GoProvider struct{}
+
+// Name возвращает имя поставщика
+func (p *GoProvider) Name() string {
+	return "go"
+}
+
+// Supports проверяет, является ли файл Go файлом
+func (p *GoProvider) Supports(file string) bool {
+	return strings.HasSuffix(file, ".go")
+}
+
+// Collect собирает объявления, на которые ссылается файл
+func (p *GoProvider) Collect(file string, content []byte) ([]Declaration, error) {
+	fset := token.NewFileSet()
+	target, err := parser.ParseFile(fset, file, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(file)
+	idents, selectors := collectReferences(target)
+	declared := declaredNames(target)
+
+	var decls []Declaration
+
+	// Объявления из других файлов того же пакета
+	samePackage, err := parseDir(fset, dir, target.Name.Name, file)
+	if err == nil {
+		for _, f := range samePackage {
+			source := relativeSource(fset, f)
+			for _, decl := range summarizeFile(fset, f, target.Name.Name, source) {
+				if declared[decl.Name] || !idents[decl.Name] {
+					continue
+				}
+				decl.Priority = 0
+				decls = append(decls, decl)
+			}
+		}
+	}
+
+	// Объявления из локальных пакетов модуля
+	modRoot, modPath := depgraph.FindModule(dir)
+	if modRoot == "" {
+		return decls, nil
+	}
+
+	for _, imp := range target.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || !strings.HasPrefix(importPath, modPath+"/") {
+			continue
+		}
+
+		alias := path.Base(importPath)
+		if imp.Name != nil {
+			alias = imp.Name.Name
+		}
+		names := selectors[alias]
+		if len(names) == 0 {
+			continue
+		}
+
+		pkgDir := filepath.Join(modRoot, filepath.FromSlash(strings.TrimPrefix(importPath, modPath+"/")))
+		files, err := parseDir(fset, pkgDir, "", "")
+		if err != nil {
+			continue
+		}
+
+		for _, f := range files {
+			source := relativeSource(fset, f)
+			for _, decl := range summarizeFile(fset, f, importPath, source) {
+				// Методы включаются, если используется их тип-получатель
+				referenced := names[decl.Name] && decl.Receiver == ""
+				if decl.Receiver != "" {
+					referenced = names[decl.Receiver] && ast.IsExported(decl.Name)
+				}
+				if !referenced {
+					continue
+				}
+				decl.Priority = 1
+				decls = append(decls, decl)
+			}
+		}
+	}
+
+	return decls, nil
+}
+
+// collectReferences собирает используемые идентификаторы и обращения вида pkg.Name
+func collectReferences(file *ast.File) (map[string]bool, map[string]map[string]bool) {
+	idents := make(map[string]bool)
+	selectors := make(map[string]map[string]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Ident:
+			idents[node.Name] = true
+		case *ast.SelectorExpr:
+			if x, ok := node.X.(*ast.Ident); ok {
+				if selectors[x.Name] == nil {
+					selectors[x.Name] = make(map[string]bool)
+				}
+				selectors[x.Name][node.Sel.Name] = true
+			}
+		}
+		return true
+	})
+
+	return idents, selectors
+}
+
+// declaredNames возвращает имена верхнеуровневых объявлений файла (кроме методов)
+func declaredNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names[s.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						names[name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// parseDir разбирает не тестовые Go файлы директории, пропуская файл exclude.
+// Если pkgName не пуст, возвращаются только файлы этого пакета.
+func parseDir(fset *token.FileSet, dir, pkgName, exclude string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeAbs, _ := filepath.Abs(exclude)
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		full := filepath.Join(dir, name)
+		if abs, _ := filepath.Abs(full); exclude != "" && abs == excludeAbs {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, full, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		if pkgName != "" && f.Name.Name != pkgName {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+// summarizeFile строит краткие описания верхнеуровневых объявлений файла
+func summarizeFile(fset *token.FileSet, file *ast.File, pkg, source string) []Declaration {
+	var decls []Declaration
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			kind := "func"
+			if d.Recv != nil {
+				kind = "method"
+			}
+			fn := *d
+			fn.Body = nil
+			fn.Doc = nil
+			decls = append(decls, Declaration{
+				Package:  pkg,
+				Source:   source,
+				Kind:     kind,
+				Name:     d.Name.Name,
+				Receiver: ReceiverName(d),
+				Summary:  printNode(fset, &fn),
+			})
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				decls = append(decls, summarizeSpec(fset, d.Tok, spec, pkg, source)...)
+			}
+		}
+	}
+
+	return decls
+}
+
+// ReceiverName возвращает имя типа-получателя метода
+func ReceiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	case *ast.IndexListExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+// summarizeSpec описывает тип, переменную или константу
+func summarizeSpec(fset *token.FileSet, tok token.Token, spec ast.Spec, pkg, source string) []Declaration {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		ts := *s
+		ts.Doc = nil
+		ts.Comment = nil
+		return []Declaration{{
+			Package: pkg,
+			Source:  source,
+			Kind:    "type",
+			Name:    s.Name.Name,
+			Summary: "type " + printNode(fset, &ts),
+		}}
+	case *ast.ValueSpec:
+		var decls []Declaration
+		for _, name := range s.Names {
+			summary := tok.String() + " " + name.Name
+			if s.Type != nil {
+				summary += " " + printNode(fset, s.Type)
+			}
+			decls = append(decls, Declaration{
+				Package: pkg,
+				Source:  source,
+				Kind:    tok.String(),
+				Name:    name.Name,
+				Summary: summary,
+			})
+		}
+		return decls
+	}
+	return nil
+}
+
+// printNode печатает узел AST без комментариев
+func printNode(fset *token.FileSet, node interface{}) string {
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.RawFormat, Tabwidth: 4}
+	if err := cfg.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// relativeSource возвращает путь файла объявления относительно рабочей директории
+func relativeSource(fset *token.FileSet, file *ast.File) string {
+	name := fset.File(file.Pos()).Name()
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, name); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(name)
+}
@@ -0,0 +1,123 @@
+package codecontext
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Provider поставщик межфайлового контекста для конкретного языка.
+// Реализации регистрируются через Register и выбираются по имени файла.
+type Provider interface {
+	// Name возвращает имя поставщика (для подробного вывода)
+	Name() string
+	// Supports проверяет, умеет ли поставщик обрабатывать файл
+	Supports(file string) bool
+	// Collect собирает объявления из других файлов, на которые ссылается файл
+	Collect(file string, content []byte) ([]Declaration, error)
+}
+
+// Declaration краткое описание объявления из другого файла
+type Declaration struct {
+	Package  string // пакет или модуль, в котором объявлен символ
+	Source   string // файл, в котором объявлен символ
+	Kind     string // func, method, type, var, const
+	Name     string
+	Receiver string // тип-получатель для методов
+	Summary  string // сигнатура, поля структуры или методы интерфейса
+	Priority int    // чем меньше, тем важнее объявление
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   []Provider
+)
+
+// Register регистрирует поставщика контекста
+func Register(p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers = append(providers, p)
+}
+
+// ProviderFor возвращает поставщика для файла или nil
+func ProviderFor(file string) Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	for _, p := range providers {
+		if p.Supports(file) {
+			return p
+		}
+	}
+	return nil
+}
+
+// EstimateTokens грубо оценивает количество токенов в тексте
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Build собирает краткую сводку связанных объявлений для файла
+// в пределах бюджета maxTokens. Для неподдерживаемых языков возвращает пустую строку.
+func Build(file string, content []byte, maxTokens int) (string, error) {
+	provider := ProviderFor(file)
+	if provider == nil {
+		return "", nil
+	}
+
+	decls, err := provider.Collect(file, content)
+	if err != nil {
+		return "", fmt.Errorf("ошибка сбора контекста (%s): %v", provider.Name(), err)
+	}
+
+	return Render(decls, maxTokens), nil
+}
+
+// Render форматирует объявления, группируя их по пакетам и укладываясь в бюджет
+func Render(decls []Declaration, maxTokens int) string {
+	if len(decls) == 0 {
+		return ""
+	}
+
+	sorted := make([]Declaration, len(decls))
+	copy(sorted, decls)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		if sorted[i].Package != sorted[j].Package {
+			return sorted[i].Package < sorted[j].Package
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var out strings.Builder
+	used := 0
+	skipped := 0
+	currentPackage := ""
+
+	for _, decl := range sorted {
+		var entry strings.Builder
+		if decl.Package != currentPackage {
+			entry.WriteString(fmt.Sprintf("// package %s\n", decl.Package))
+		}
+		entry.WriteString(fmt.Sprintf("%s // %s\n", decl.Summary, decl.Source))
+
+		cost := EstimateTokens(entry.String())
+		if maxTokens > 0 && used+cost > maxTokens {
+			skipped++
+			continue
+		}
+
+		out.WriteString(entry.String())
+		used += cost
+		currentPackage = decl.Package
+	}
+
+	if skipped > 0 {
+		out.WriteString(fmt.Sprintf("// ... пропущено объявлений из-за лимита токенов: %d\n", skipped))
+	}
+
+	return out.String()
+}
@@ -0,0 +1,224 @@
+package depgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+// CmdImport импорт пакета cmd из другого пакета модуля
+type CmdImport struct {
+	Package string // пакет, который импортирует cmd
+	Import  Import // объявление импорта
+}
+
+// Metrics вычисляет fan-in, fan-out и нестабильность для всех пакетов
+func (g *Graph) Metrics() []Metrics {
+	var metrics []Metrics
+	for _, pkg := range g.SortedPackages() {
+		fanIn := len(pkg.ImportedBy)
+		fanOut := len(pkg.ImportedPackages())
+
+		instability := 0.0
+		if fanIn+fanOut > 0 {
+			instability = float64(fanOut) / float64(fanIn+fanOut)
+		}
+
+		metrics = append(metrics, Metrics{
+			Package:     pkg.ImportPath,
+			FanIn:       fanIn,
+			FanOut:      fanOut,
+			Instability: instability,
+		})
+	}
+	return metrics
+}
+
+// Cycles находит циклы импортов (сильно связные компоненты из нескольких пакетов)
+// алгоритмом Тарьяна. Пакеты в каждом цикле отсортированы.
+func (g *Graph) Cycles() [][]string {
+	index := 0
+	indices := make(map[string]int)
+	lowlinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var strongConnect func(name string)
+	strongConnect = func(name string) {
+		indices[name] = index
+		lowlinks[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, next := range g.Packages[name].ImportedPackages() {
+			if _, exists := g.Packages[next]; !exists {
+				continue
+			}
+			if _, visited := indices[next]; !visited {
+				strongConnect(next)
+				if lowlinks[next] < lowlinks[name] {
+					lowlinks[name] = lowlinks[next]
+				}
+			} else if onStack[next] && indices[next] < lowlinks[name] {
+				lowlinks[name] = indices[next]
+			}
+		}
+
+		if lowlinks[name] != indices[name] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == name {
+				break
+			}
+		}
+
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, pkg := range g.SortedPackages() {
+		if _, visited := indices[pkg.ImportPath]; !visited {
+			strongConnect(pkg.ImportPath)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+// CmdImports находит пакеты вне cmd, которые импортируют cmd или его подпакеты.
+// Слой команд должен зависеть от внутренних пакетов, а не наоборот; точки входа
+// (пакеты main) вправе подключать команды.
+func (g *Graph) CmdImports() []CmdImport {
+	var result []CmdImport
+	for _, pkg := range g.SortedPackages() {
+		if isCmdPackage(pkg.Dir) || pkg.Name == "main" {
+			continue
+		}
+		for _, imp := range pkg.Imports {
+			if isCmdPackage(g.RelativePath(imp.Path)) {
+				result = append(result, CmdImport{Package: pkg.ImportPath, Import: imp})
+			}
+		}
+	}
+	return result
+}
+
+// isCmdPackage проверяет, относится ли каталог к слою команд
+func isCmdPackage(dir string) bool {
+	return dir == "cmd" || strings.HasPrefix(dir, "cmd/")
+}
+
+// Findings возвращает детерминированные архитектурные проблемы графа:
+// циклы импортов и импорты пакета cmd. Пути файлов указаны относительно корня модуля.
+func (g *Graph) Findings() []types.Issue {
+	var issues []types.Issue
+
+	for _, cycle := range g.Cycles() {
+		issue := types.Issue{
+			Type:       "architecture",
+			Severity:   "high",
+			Message:    fmt.Sprintf("Цикл импортов между пакетами: %s", g.formatCycle(cycle)),
+			Suggestion: "Разорвите цикл: вынесите общие типы в отдельный пакет или инвертируйте зависимость через интерфейс",
+			Reasoning:  "Найдено статическим анализом графа импортов (go/parser)",
+		}
+
+		// Указываем на импорт, замыкающий цикл из первого пакета
+		first := g.Packages[cycle[0]]
+		for _, other := range cycle[1:] {
+			if imp, ok := first.FindImport(other); ok {
+				issue.File = imp.File
+				issue.Line = imp.Line
+				break
+			}
+		}
+
+		issues = append(issues, issue)
+	}
+
+	for _, cmdImport := range g.CmdImports() {
+		issues = append(issues, types.Issue{
+			Type:       "architecture",
+			Severity:   "high",
+			Message:    fmt.Sprintf("Пакет %s импортирует слой команд %s", g.RelativePath(cmdImport.Package), g.RelativePath(cmdImport.Import.Path)),
+			Suggestion: "Перенесите нужную логику из cmd во внутренний пакет; cmd должен только использовать internal",
+			Reasoning:  "Найдено статическим анализом графа импортов (go/parser)",
+			File:       cmdImport.Import.File,
+			Line:       cmdImport.Import.Line,
+		})
+	}
+
+	return issues
+}
+
+// formatCycle форматирует цикл как цепочку относительных путей
+func (g *Graph) formatCycle(cycle []string) string {
+	var names []string
+	for _, name := range cycle {
+		names = append(names, g.RelativePath(name))
+	}
+	return strings.Join(names, " ↔ ")
+}
+
+// Render формирует текстовое описание графа и метрик для промпта модели
+func (g *Graph) Render() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("Модуль: %s (%d пакетов)\n", g.Module, len(g.Packages)))
+	builder.WriteString("Импорты между пакетами модуля:\n")
+	for _, pkg := range g.SortedPackages() {
+		imported := pkg.ImportedPackages()
+		if len(imported) == 0 {
+			builder.WriteString(fmt.Sprintf("  %s -> (нет)\n", g.RelativePath(pkg.ImportPath)))
+			continue
+		}
+
+		var names []string
+		for _, name := range imported {
+			names = append(names, g.RelativePath(name))
+		}
+		builder.WriteString(fmt.Sprintf("  %s -> %s\n", g.RelativePath(pkg.ImportPath), strings.Join(names, ", ")))
+	}
+
+	builder.WriteString("Метрики (fan-in, fan-out, нестабильность I = fan-out / (fan-in + fan-out)):\n")
+	for _, m := range g.Metrics() {
+		builder.WriteString(fmt.Sprintf("  %s: fan-in=%d fan-out=%d I=%.2f\n", g.RelativePath(m.Package), m.FanIn, m.FanOut, m.Instability))
+	}
+
+	cycles := g.Cycles()
+	if len(cycles) == 0 {
+		builder.WriteString("Циклы импортов: нет\n")
+	} else {
+		builder.WriteString("Циклы импортов:\n")
+		for _, cycle := range cycles {
+			builder.WriteString(fmt.Sprintf("  %s\n", g.formatCycle(cycle)))
+		}
+	}
+
+	cmdImports := g.CmdImports()
+	if len(cmdImports) == 0 {
+		builder.WriteString("Импорты cmd из других пакетов: нет\n")
+	} else {
+		builder.WriteString("Импорты cmd из других пакетов:\n")
+		for _, cmdImport := range cmdImports {
+			builder.WriteString(fmt.Sprintf("  %s -> %s (%s:%d)\n", g.RelativePath(cmdImport.Package), g.RelativePath(cmdImport.Import.Path), cmdImport.Import.File, cmdImport.Import.Line))
+		}
+	}
+
+	return builder.String()
+}
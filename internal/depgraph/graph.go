@@ -0,0 +1,249 @@
+package depgraph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Import импорт пакета с позицией в исходном файле
+type Import struct {
+	Path string // полный путь импортируемого пакета
+	File string // файл, в котором объявлен импорт
+	Line int    // строка объявления импорта
+}
+
+// Package пакет модуля и его зависимости внутри модуля
+type Package struct {
+	ImportPath string   // полный путь пакета
+	Name       string   // имя пакета из объявления package
+	Dir        string   // каталог пакета относительно корня модуля
+	Files      []string // Go файлы пакета (без тестов)
+	Imports    []Import // импорты пакетов этого же модуля
+	External   []string // импорты внешних и стандартных пакетов
+	ImportedBy []string // пакеты модуля, импортирующие этот пакет
+}
+
+// Graph граф импортов пакетов Go модуля
+type Graph struct {
+	Root     string              // абсолютный путь к корню модуля
+	Module   string              // путь модуля из go.mod
+	Packages map[string]*Package // пакеты по полному пути импорта
+}
+
+// Metrics метрики связности пакета
+type Metrics struct {
+	Package     string
+	FanIn       int     // сколько пакетов модуля зависят от пакета (Ca)
+	FanOut      int     // от скольких пакетов модуля зависит пакет (Ce)
+	Instability float64 // Ce / (Ca + Ce): 0 - стабильный, 1 - нестабильный
+}
+
+// skippedDirs каталоги, которые не участвуют в построении графа
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"testdata":     true,
+	"node_modules": true,
+}
+
+// FindModule ищет go.mod вверх по дереву и возвращает корень и путь модуля
+func FindModule(dir string) (string, string) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(abs, "go.mod"))
+		if err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					return abs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+				}
+			}
+			return "", ""
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", ""
+		}
+		abs = parent
+	}
+}
+
+// Build строит граф импортов модуля, в который входит каталог dir.
+// Разбираются только объявления импортов (parser.ImportsOnly), тестовые файлы пропускаются.
+func Build(dir string) (*Graph, error) {
+	root, module := FindModule(dir)
+	if root == "" {
+		return nil, fmt.Errorf("go.mod не найден для %s", dir)
+	}
+
+	graph := &Graph{
+		Root:     root,
+		Module:   module,
+		Packages: make(map[string]*Package),
+	}
+
+	fset := token.NewFileSet()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (skippedDirs[name] || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			// Вложенные модули анализируются отдельно
+			if path != root {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		return graph.addFile(fset, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph.link()
+	return graph, nil
+}
+
+// addFile добавляет импорты файла в граф
+func (g *Graph) addFile(fset *token.FileSet, path string) error {
+	file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+	if err != nil {
+		// Файлы с синтаксическими ошибками не должны ломать построение графа
+		return nil
+	}
+
+	relDir, err := filepath.Rel(g.Root, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	relDir = filepath.ToSlash(relDir)
+
+	importPath := g.Module
+	if relDir != "." {
+		importPath = g.Module + "/" + relDir
+	}
+
+	pkg, exists := g.Packages[importPath]
+	if !exists {
+		pkg = &Package{ImportPath: importPath, Name: file.Name.Name, Dir: relDir}
+		g.Packages[importPath] = pkg
+	}
+
+	relFile, _ := filepath.Rel(g.Root, path)
+	pkg.Files = append(pkg.Files, filepath.ToSlash(relFile))
+
+	for _, spec := range file.Imports {
+		imported, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if g.IsLocal(imported) {
+			pkg.Imports = append(pkg.Imports, Import{
+				Path: imported,
+				File: filepath.ToSlash(relFile),
+				Line: fset.Position(spec.Pos()).Line,
+			})
+		} else {
+			pkg.External = AppendUnique(pkg.External, imported)
+		}
+	}
+
+	return nil
+}
+
+// link заполняет обратные ребра ImportedBy
+func (g *Graph) link() {
+	for _, pkg := range g.Packages {
+		for _, imported := range pkg.ImportedPackages() {
+			if target, exists := g.Packages[imported]; exists {
+				target.ImportedBy = AppendUnique(target.ImportedBy, pkg.ImportPath)
+			}
+		}
+	}
+
+	for _, pkg := range g.Packages {
+		sort.Strings(pkg.ImportedBy)
+		sort.Strings(pkg.External)
+	}
+}
+
+// IsLocal проверяет, принадлежит ли путь импорта модулю
+func (g *Graph) IsLocal(importPath string) bool {
+	return importPath == g.Module || strings.HasPrefix(importPath, g.Module+"/")
+}
+
+// RelativePath возвращает путь пакета относительно модуля ("." для корня)
+func (g *Graph) RelativePath(importPath string) string {
+	if importPath == g.Module {
+		return "."
+	}
+	return strings.TrimPrefix(importPath, g.Module+"/")
+}
+
+// SortedPackages возвращает пакеты, отсортированные по пути импорта
+func (g *Graph) SortedPackages() []*Package {
+	packages := make([]*Package, 0, len(g.Packages))
+	for _, pkg := range g.Packages {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].ImportPath < packages[j].ImportPath
+	})
+	return packages
+}
+
+// ImportedPackages возвращает уникальные пакеты модуля, импортируемые пакетом
+func (p *Package) ImportedPackages() []string {
+	var imported []string
+	for _, imp := range p.Imports {
+		imported = AppendUnique(imported, imp.Path)
+	}
+	sort.Strings(imported)
+	return imported
+}
+
+// FindImport возвращает первое объявление импорта пакета target
+func (p *Package) FindImport(target string) (Import, bool) {
+	for _, imp := range p.Imports {
+		if imp.Path == target {
+			return imp, true
+		}
+	}
+	return Import{}, false
+}
+
+// AppendUnique добавляет строку в срез, если ее там еще нет
+func AppendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,221 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFiles файлы с правилами игнорирования, которые читаются в каждой директории.
+// Правила .miniReviewerignore применяются после .gitignore и могут их отменять.
+var IgnoreFiles = []string{".gitignore", ".miniReviewerignore"}
+
+// ignoreRule правило игнорирования в формате .gitignore
+type ignoreRule struct {
+	source   string         // исходный паттерн
+	base     string         // директория файла правил относительно корня; "" - корень
+	negate   bool           // "!паттерн" возвращает ранее исключенный путь
+	dirOnly  bool           // "паттерн/" совпадает только с директориями
+	anchored bool           // паттерн со "/" сопоставляется с путем от base, иначе с именем
+	pattern  *regexp.Regexp // паттерн, преобразованный в регулярное выражение
+}
+
+// IgnoreMatcher проверяет пути по правилам в формате .gitignore: паттернам
+// конфигурации и файлам IgnoreFiles в каждой директории. Как и в git, для пути
+// действует последнее совпавшее правило; правила более глубоких директорий
+// важнее, а паттерны конфигурации и флагов --ignore важнее файлов правил.
+type IgnoreMatcher struct {
+	root     string
+	patterns []ignoreRule
+	dirRules map[string][]ignoreRule // правила файлов IgnoreFiles по директориям
+}
+
+// NewIgnoreMatcher создает проверку путей относительно root по паттернам конфигурации
+// и файлам правил, которые читаются по мере обращения к директориям
+func NewIgnoreMatcher(root string, patterns []string) *IgnoreMatcher {
+	matcher := &IgnoreMatcher{
+		root:     root,
+		dirRules: make(map[string][]ignoreRule),
+	}
+	for _, pattern := range patterns {
+		if rule, ok := parseIgnoreRule(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), ""); ok {
+			matcher.patterns = append(matcher.patterns, rule)
+		}
+	}
+	return matcher
+}
+
+// Match возвращает паттерн, по которому игнорируется путь (относительно root), или
+// пустую строку. Путь игнорируется и тогда, когда игнорируется одна из его директорий.
+func (m *IgnoreMatcher) Match(path string, isDir bool) string {
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		if pattern := m.matchPath(strings.Join(parts[:i], "/"), true); pattern != "" {
+			return pattern
+		}
+	}
+	return m.matchPath(path, isDir)
+}
+
+// matchPath проверяет сам путь без его директорий: при обходе директорий
+// содержимое исключенных директорий не посещается
+func (m *IgnoreMatcher) matchPath(path string, isDir bool) string {
+	var decided *ignoreRule
+
+	// Файлы правил корня и всех директорий пути, от внешних к вложенным
+	dirs := []string{""}
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			dirs = append(dirs, path[:i])
+		}
+	}
+
+	for _, dir := range dirs {
+		rules := m.rulesFor(dir)
+		for i := range rules {
+			if rules[i].matches(path, isDir) {
+				decided = &rules[i]
+			}
+		}
+	}
+
+	for i := range m.patterns {
+		if m.patterns[i].matches(path, isDir) {
+			decided = &m.patterns[i]
+		}
+	}
+
+	if decided == nil || decided.negate {
+		return ""
+	}
+	return decided.source
+}
+
+// rulesFor возвращает правила файлов IgnoreFiles директории dir
+func (m *IgnoreMatcher) rulesFor(dir string) []ignoreRule {
+	if rules, ok := m.dirRules[dir]; ok {
+		return rules
+	}
+
+	var rules []ignoreRule
+	for _, name := range IgnoreFiles {
+		content, err := os.ReadFile(filepath.Join(m.root, filepath.FromSlash(dir), name))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if rule, ok := parseIgnoreRule(line, dir); ok {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	m.dirRules[dir] = rules
+	return rules
+}
+
+// matches проверяет путь относительно корня по правилу
+func (r *ignoreRule) matches(path string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	relative := path
+	if r.base != "" {
+		if !strings.HasPrefix(path, r.base+"/") {
+			return false
+		}
+		relative = path[len(r.base)+1:]
+	}
+	if !r.anchored {
+		relative = relative[strings.LastIndex(relative, "/")+1:]
+	}
+
+	return r.pattern.MatchString(relative)
+}
+
+// parseIgnoreRule разбирает строку файла правил; пустые строки и комментарии пропускаются
+func parseIgnoreRule(line, base string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if !strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{source: line, base: base}
+	switch {
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	pattern, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globToRegexp преобразует glob в формате .gitignore в регулярное выражение:
+// "*" и "?" не переходят через "/", "**/" - любое количество директорий,
+// "/**" в конце - все содержимое директории
+func globToRegexp(glob string) string {
+	var expr strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				if i+2 < len(glob) && glob[i+2] == '/' {
+					expr.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					expr.WriteString(".*")
+					i++
+				}
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return expr.String()
+}
@@ -0,0 +1,111 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*.min.js", "app.min.js", true},
+		{"*.min.js", "app.js", false},
+		{"*.min.js", "dist/app.min.js", false},
+		{"vendor/*", "vendor/lib", true},
+		{"vendor/*", "vendor/lib/a.go", false},
+		{"**/foo", "foo", true},
+		{"**/foo", "a/b/foo", true},
+		{"**/foo", "a/foobar", false},
+		{"foo/**", "foo/a", true},
+		{"foo/**", "foo/a/b.go", true},
+		{"foo/**", "foo", false},
+		{"a?c", "abc", true},
+		{"a?c", "a/c", false},
+		{"[!a]b", "cb", true},
+		{"[!a]b", "ab", false},
+		{`\*.go`, "*.go", true},
+		{`\*.go`, "a.go", false},
+	}
+
+	for _, tt := range tests {
+		pattern := regexp.MustCompile("^" + globToRegexp(tt.glob) + "$")
+		if got := pattern.MatchString(tt.path); got != tt.match {
+			t.Errorf("%q ~ %q: got %v, want %v", tt.glob, tt.path, got, tt.match)
+		}
+	}
+}
+
+func TestIgnoreMatcherMatch(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".gitignore", "vendor/*\n!vendor/keep\n*.min.js\n**/generated\ncache/**\nbuild/\n!build/keep.txt\nlogs\n")
+	writeFile(t, root, "web/.gitignore", "*.css\n")
+	writeFile(t, root, "web/.miniReviewerignore", "!main.css\nstatic/\n")
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  string
+	}{
+		{"vendor/lib", true, "vendor/*"},
+		{"vendor/lib/a.go", false, "vendor/*"},
+		{"vendor/keep", false, ""},
+		{"vendor", true, ""},
+		{"app.min.js", false, "*.min.js"},
+		{"web/js/app.min.js", false, "*.min.js"},
+		{"app.js", false, ""},
+		{"generated", true, "**/generated"},
+		{"api/v1/generated/types.go", false, "**/generated"},
+		{"cache/a/b.go", false, "cache/**"},
+		{"cache", true, ""},
+		{"build", true, "build/"},
+		{"build/keep.txt", false, "build/"},
+		{"src/build", true, "build/"},
+		{"src/build", false, ""},
+		{"logs", false, "logs"},
+		{"web/theme.css", false, "*.css"},
+		{"web/main.css", false, ""},
+		{"main.css", false, ""},
+		{"web/static/a.js", false, "static/"},
+		{"static/a.js", false, ""},
+	}
+
+	matcher := NewIgnoreMatcher(root, nil)
+	for _, tt := range tests {
+		if got := matcher.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v): got %q, want %q", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreMatcherPatternsOverrideFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".gitignore", "*.log\n")
+
+	matcher := NewIgnoreMatcher(root, []string{"!debug.log", "./docs/"})
+	if got := matcher.Match("debug.log", false); got != "" {
+		t.Errorf("debug.log: got %q, want not ignored", got)
+	}
+	if got := matcher.Match("error.log", false); got != "*.log" {
+		t.Errorf("error.log: got %q, want *.log", got)
+	}
+	if got := matcher.Match("docs/index.md", false); got != "docs/" {
+		t.Errorf("docs/index.md: got %q, want docs/", got)
+	}
+}
+
+// writeFile создает файл с содержимым content в директории root
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,301 @@
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// Виды модулей монорепозитория
+const (
+	ModuleGo     = "go"
+	ModuleNode   = "node"
+	ModulePython = "python"
+	ModuleRust   = "rust"
+)
+
+// moduleManifests файлы, которые делают директорию корнем модуля, в порядке
+// приоритета: директория с go.mod и package.json считается Go модулем
+var moduleManifests = []struct {
+	file string
+	kind string
+}{
+	{"go.mod", ModuleGo},
+	{"package.json", ModuleNode},
+	{"pyproject.toml", ModulePython},
+	{"Cargo.toml", ModuleRust},
+}
+
+// workspaceDeclaration объявление участников рабочего пространства:
+// go.work, workspaces в package.json, pnpm-workspace.yaml, [workspace] в Cargo.toml
+type workspaceDeclaration struct {
+	file    string   // файл объявления относительно корня
+	dir     string   // директория файла объявления
+	kind    string   // вид модулей-участников
+	members []string // пути или glob участников относительно dir; "!" исключает
+}
+
+// DetectModules находит корни модулей в root по go.mod, package.json,
+// pyproject.toml и Cargo.toml. Игнорируемые директории, сторонний код и testdata
+// не просматриваются. Модули, объявленные участниками рабочего пространства
+// (go.work, workspaces в package.json, pnpm-workspace.yaml, [workspace] в
+// Cargo.toml), помечаются файлом объявления. Пути модулей задаются относительно
+// root, корневой модуль имеет путь ".".
+func (s *Scanner) DetectModules(root string) ([]types.WorkspaceModule, error) {
+	var modules []types.WorkspaceModule
+	var declarations []workspaceDeclaration
+
+	err := s.walk(root, func(dir string, info os.FileInfo) {
+		if !info.IsDir() {
+			return
+		}
+		relative, err := filepath.Rel(root, dir)
+		if err != nil {
+			return
+		}
+		relative = filepath.ToSlash(relative)
+		for _, part := range strings.Split(relative, "/") {
+			if part == "testdata" {
+				return
+			}
+		}
+
+		declarations = append(declarations, readWorkspaceDeclarations(dir, relative)...)
+		if module, ok := readModule(dir, relative); ok {
+			modules = append(modules, module)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, declaration := range declarations {
+		for i := range modules {
+			if modules[i].Workspace == "" && modules[i].Kind == declaration.kind && declaration.includes(modules[i].Path) {
+				modules[i].Workspace = declaration.file
+			}
+		}
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Path < modules[j].Path
+	})
+	return modules, nil
+}
+
+// ModuleOf возвращает самый вложенный модуль, которому принадлежит файл, или nil
+func ModuleOf(modules []types.WorkspaceModule, file string) *types.WorkspaceModule {
+	file = path.Clean(filepath.ToSlash(file))
+
+	var owner *types.WorkspaceModule
+	for i := range modules {
+		if ContainsPath(modules[i].Path, file) && (owner == nil || len(modules[i].Path) > len(owner.Path)) {
+			owner = &modules[i]
+		}
+	}
+	return owner
+}
+
+// NestedModules возвращает модули, вложенные в директорию dir
+func NestedModules(modules []types.WorkspaceModule, dir string) []types.WorkspaceModule {
+	dir = path.Clean(filepath.ToSlash(dir))
+
+	var nested []types.WorkspaceModule
+	for _, module := range modules {
+		if module.Path != dir && ContainsPath(dir, module.Path) {
+			nested = append(nested, module)
+		}
+	}
+	return nested
+}
+
+// ContainsPath проверяет, находится ли путь file внутри директории dir (или совпадает с ней)
+func ContainsPath(dir, file string) bool {
+	dir = path.Clean(filepath.ToSlash(dir))
+	file = path.Clean(filepath.ToSlash(file))
+	return dir == "." || file == dir || strings.HasPrefix(file, dir+"/")
+}
+
+// readModule читает манифест модуля в директории dir
+func readModule(dir, relative string) (types.WorkspaceModule, bool) {
+	for _, manifest := range moduleManifests {
+		content, err := os.ReadFile(filepath.Join(dir, manifest.file))
+		if err != nil {
+			continue
+		}
+
+		name, ok := manifestName(manifest.kind, content)
+		if !ok {
+			// Cargo.toml только с [workspace] - корень рабочего пространства, а не модуль
+			continue
+		}
+		if name == "" {
+			name = path.Base(relative)
+		}
+		return types.WorkspaceModule{Name: name, Path: relative, Kind: manifest.kind}, true
+	}
+	return types.WorkspaceModule{}, false
+}
+
+// manifestName возвращает имя модуля из манифеста. false означает, что манифест
+// не описывает модуль.
+func manifestName(kind string, content []byte) (string, bool) {
+	switch kind {
+	case ModuleGo:
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "module ") {
+				return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), true
+			}
+		}
+		return "", true
+	case ModuleNode:
+		var manifest struct {
+			Name string `json:"name"`
+		}
+		_ = json.Unmarshal(content, &manifest)
+		return manifest.Name, true
+	case ModulePython:
+		config := readConfig("toml", content)
+		if name := config.GetString("project.name"); name != "" {
+			return name, true
+		}
+		return config.GetString("tool.poetry.name"), true
+	case ModuleRust:
+		config := readConfig("toml", content)
+		if !config.IsSet("package") {
+			return "", false
+		}
+		return config.GetString("package.name"), true
+	}
+	return "", false
+}
+
+// readWorkspaceDeclarations читает объявления рабочих пространств в директории dir
+func readWorkspaceDeclarations(dir, relative string) []workspaceDeclaration {
+	var declarations []workspaceDeclaration
+	declare := func(file, kind string, members []string) {
+		if len(members) > 0 {
+			declarations = append(declarations, workspaceDeclaration{
+				file:    path.Join(relative, file),
+				dir:     relative,
+				kind:    kind,
+				members: members,
+			})
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "go.work")); err == nil {
+		declare("go.work", ModuleGo, goWorkUses(content))
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		declare("package.json", ModuleNode, packageJSONWorkspaces(content))
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
+		declare("pnpm-workspace.yaml", ModuleNode, readConfig("yaml", content).GetStringSlice("packages"))
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+		config := readConfig("toml", content)
+		members := config.GetStringSlice("workspace.members")
+		for _, excluded := range config.GetStringSlice("workspace.exclude") {
+			members = append(members, "!"+excluded)
+		}
+		declare("Cargo.toml", ModuleRust, members)
+	}
+
+	return declarations
+}
+
+// goWorkUses возвращает директории из директив use файла go.work
+func goWorkUses(content []byte) []string {
+	var uses []string
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, "//"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "":
+			uses = append(uses, strings.Trim(line, `"`))
+		case line == "use (":
+			inBlock = true
+		case strings.HasPrefix(line, "use "):
+			uses = append(uses, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return uses
+}
+
+// packageJSONWorkspaces возвращает паттерны workspaces из package.json: список
+// (npm, yarn) или объект с полем packages (yarn classic)
+func packageJSONWorkspaces(content []byte) []string {
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil || len(manifest.Workspaces) == 0 {
+		return nil
+	}
+
+	var members []string
+	if err := json.Unmarshal(manifest.Workspaces, &members); err == nil {
+		return members
+	}
+
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	_ = json.Unmarshal(manifest.Workspaces, &object)
+	return object.Packages
+}
+
+// readConfig разбирает содержимое манифеста в формате toml или yaml
+func readConfig(format string, content []byte) *viper.Viper {
+	config := viper.New()
+	config.SetConfigType(format)
+	_ = config.ReadConfig(bytes.NewReader(content))
+	return config
+}
+
+// includes проверяет, объявлен ли модуль с путем modulePath участником рабочего
+// пространства. Как и в pnpm и Cargo, последний совпавший паттерн с "!" исключает модуль.
+func (d workspaceDeclaration) includes(modulePath string) bool {
+	if !ContainsPath(d.dir, modulePath) {
+		return false
+	}
+	relative := modulePath
+	if d.dir != "." {
+		relative = strings.TrimPrefix(strings.TrimPrefix(modulePath, d.dir), "/")
+	}
+	if relative == "" {
+		relative = "."
+	}
+
+	included := false
+	for _, member := range d.members {
+		negate := strings.HasPrefix(member, "!")
+		member = path.Clean(strings.TrimPrefix(strings.TrimPrefix(member, "!"), "./"))
+
+		pattern, err := regexp.Compile("^" + globToRegexp(member) + "$")
+		if err == nil && pattern.MatchString(relative) {
+			included = !negate
+		}
+	}
+	return included
+}
@@ -6,12 +6,15 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"miniReviewer/internal/types"
 )
 
 // Scanner сканер файловой системы
 type Scanner struct {
 	ignorePatterns []string
 	maxFileSize    int64
+	skipped        []types.SkippedFile
 }
 
 // NewScanner создает новый сканер файловой системы
@@ -24,44 +27,16 @@ func NewScanner(ignorePatterns []string, maxFileSize int64) *Scanner {
 
 // FindGoFiles находит все Go файлы в директории
 func (s *Scanner) FindGoFiles(root string) ([]string, error) {
-	var files []string
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() && strings.HasSuffix(path, ".go") {
-			// Проверяем размер файла
-			if s.maxFileSize > 0 && info.Size() > s.maxFileSize {
-				return nil
-			}
-			// Проверяем паттерны игнорирования
-			if !s.shouldIgnoreFile(path) {
-				files = append(files, path)
-			}
-		}
-		return nil
-	})
-	return files, err
+	return s.FindFilesByExtension(root, ".go")
 }
 
 // FindFilesByExtension находит файлы по расширению
 func (s *Scanner) FindFilesByExtension(root, extension string) ([]string, error) {
 	var files []string
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() && strings.HasSuffix(path, extension) {
-			// Проверяем размер файла
-			if s.maxFileSize > 0 && info.Size() > s.maxFileSize {
-				return nil
-			}
-			// Проверяем паттерны игнорирования
-			if !s.shouldIgnoreFile(path) {
-				files = append(files, path)
-			}
+	err := s.walk(root, func(path string, info os.FileInfo) {
+		if !info.IsDir() && strings.HasSuffix(path, extension) && s.accept(path, info) {
+			files = append(files, path)
 		}
-		return nil
 	})
 	return files, err
 }
@@ -70,40 +45,107 @@ func (s *Scanner) FindFilesByExtension(root, extension string) ([]string, error)
 func (s *Scanner) FindSupportedFiles(root string) ([]string, error) {
 	var files []string
 	supportedExtensions := []string{".go", ".js", ".ts", ".py", ".java", ".cpp", ".rs", ".kt"}
-	
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+
+	err := s.walk(root, func(path string, info os.FileInfo) {
+		if info.IsDir() {
+			return
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		for _, supportedExt := range supportedExtensions {
+			if ext == supportedExt {
+				if s.accept(path, info) {
+					files = append(files, path)
+				}
+				break
+			}
+		}
+	})
+	return files, err
+}
+
+// Skipped возвращает файлы, пропущенные при сканировании, с причинами
+func (s *Scanner) Skipped() []types.SkippedFile {
+	return s.skipped
+}
+
+// accept проверяет размер и содержимое найденного файла: слишком большие,
+// сгенерированные, минифицированные и бинарные файлы пропускаются с причиной
+func (s *Scanner) accept(path string, info os.FileInfo) bool {
+	if s.maxFileSize > 0 && info.Size() > s.maxFileSize {
+		s.skip(path, SkipTooLarge, fmt.Sprintf("%s > %s", FormatSize(info.Size()), FormatSize(s.maxFileSize)))
+		return false
+	}
+
+	head, err := ReadHead(path)
+	if err != nil {
+		// Ошибка чтения сообщается при анализе файла
+		return true
+	}
+	if reason, detail := DetectSkip(path, head); reason != "" {
+		s.skip(path, reason, detail)
+		return false
+	}
+	return true
+}
+
+// skip запоминает пропущенный файл
+func (s *Scanner) skip(path, reason, detail string) {
+	s.skipped = append(s.skipped, types.SkippedFile{File: path, Reason: reason, Detail: detail})
+}
+
+// walk обходит root и вызывает visit для директорий и файлов, которые не
+// игнорируются паттернами сканера, .gitignore и .miniReviewerignore. В
+// игнорируемые директории, директории стороннего кода и .git обход не заходит.
+func (s *Scanner) walk(root string, visit func(path string, info os.FileInfo)) error {
+	matcher, prefix := s.ignoreMatcher(root)
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			ext := strings.ToLower(filepath.Ext(path))
-			for _, supportedExt := range supportedExtensions {
-				if ext == supportedExt {
-					// Проверяем размер файла
-					if s.maxFileSize > 0 && info.Size() > s.maxFileSize {
-						return nil
-					}
-					// Проверяем паттерны игнорирования
-					if !s.shouldIgnoreFile(path) {
-						files = append(files, path)
-					}
-					break
-				}
+		if path == root {
+			visit(path, info)
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(filepath.Join(prefix, relative))
+
+		if info.IsDir() {
+			if info.Name() == ".git" || matcher.matchPath(relative, true) != "" {
+				return filepath.SkipDir
+			}
+			if IsVendoredDirName(info.Name()) {
+				s.skip(path+"/", SkipVendored, "")
+				return filepath.SkipDir
 			}
+		} else if matcher.matchPath(relative, false) != "" {
+			return nil
 		}
+
+		visit(path, info)
 		return nil
 	})
-	return files, err
 }
 
-// shouldIgnoreFile проверяет, должен ли файл быть проигнорирован
-func (s *Scanner) shouldIgnoreFile(file string) bool {
-	for _, pattern := range s.ignorePatterns {
-		if strings.Contains(file, pattern) {
-			return true
+// ignoreMatcher возвращает проверку игнорирования для обхода root и путь root
+// относительно корня проверки. Корень - текущая директория (корень репозитория),
+// если root находится внутри нее, иначе сам root.
+func (s *Scanner) ignoreMatcher(root string) (*IgnoreMatcher, string) {
+	absRoot, rootErr := filepath.Abs(root)
+	cwd, cwdErr := os.Getwd()
+	if rootErr == nil && cwdErr == nil {
+		if relative, err := filepath.Rel(cwd, absRoot); err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			if relative == "." {
+				relative = ""
+			}
+			return NewIgnoreMatcher(cwd, s.ignorePatterns), relative
 		}
 	}
-	return false
+	return NewIgnoreMatcher(root, s.ignorePatterns), ""
 }
 
 // AnalyzeProjectStructure анализирует структуру проекта
@@ -111,14 +153,10 @@ func (s *Scanner) AnalyzeProjectStructure(root string) (string, error) {
 	var structure strings.Builder
 	structure.WriteString("Структура проекта:\n")
 
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
+	err := s.walk(root, func(path string, info os.FileInfo) {
 		relPath, _ := filepath.Rel(root, path)
 		if relPath == "." {
-			return nil
+			return
 		}
 
 		depth := strings.Count(relPath, string(os.PathSeparator))
@@ -129,8 +167,6 @@ func (s *Scanner) AnalyzeProjectStructure(root string) (string, error) {
 		} else {
 			structure.WriteString(fmt.Sprintf("%s📄 %s\n", indent, filepath.Base(path)))
 		}
-
-		return nil
 	})
 
 	return structure.String(), err
@@ -0,0 +1,239 @@
+package filesystem
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Причины, по которым найденный файл не анализируется
+const (
+	SkipTooLarge  = "too_large"
+	SkipGenerated = "generated"
+	SkipLockfile  = "lockfile"
+	SkipMinified  = "minified"
+	SkipVendored  = "vendored"
+	SkipBinary    = "binary"
+)
+
+// SkipReasonName возвращает описание причины пропуска файла для вывода
+func SkipReasonName(reason string) string {
+	switch reason {
+	case SkipTooLarge:
+		return "слишком большой"
+	case SkipGenerated:
+		return "сгенерированный код"
+	case SkipLockfile:
+		return "lockfile"
+	case SkipMinified:
+		return "минифицированный"
+	case SkipVendored:
+		return "сторонний код"
+	case SkipBinary:
+		return "бинарный"
+	}
+	return reason
+}
+
+// sniffSize объем начала файла, по которому определяются бинарное содержимое,
+// маркеры сгенерированного кода и минификация
+const sniffSize = 8000
+
+// vendoredDirs директории со сторонним кодом
+var vendoredDirs = map[string]bool{
+	"vendor":           true,
+	"node_modules":     true,
+	"bower_components": true,
+	"third_party":      true,
+	"site-packages":    true,
+	".venv":            true,
+	"venv":             true,
+}
+
+// lockfiles файлы зависимостей, которые генерируются менеджерами пакетов
+var lockfiles = map[string]bool{
+	"package-lock.json":   true,
+	"npm-shrinkwrap.json": true,
+	"yarn.lock":           true,
+	"pnpm-lock.yaml":      true,
+	"go.sum":              true,
+	"Cargo.lock":          true,
+	"poetry.lock":         true,
+	"Pipfile.lock":        true,
+	"composer.lock":       true,
+	"Gemfile.lock":        true,
+	"gradle.lockfile":     true,
+}
+
+var (
+	// goGeneratedPattern стандартный маркер сгенерированного Go кода
+	goGeneratedPattern = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+	// generatedMarkerPattern маркеры генераторов других языков в начале файла
+	generatedMarkerPattern = regexp.MustCompile(`(?i)@generated\b|<auto-generated|\bgenerated by\b.*\bdo not edit\b|\bautogenerated\b.*\bdo not edit\b`)
+)
+
+// DetectSkip определяет по пути и началу содержимого (head может быть nil), нужно
+// ли пропустить файл: lockfile, минифицированный бандл, код в vendored директории,
+// бинарное содержимое или сгенерированный код. Возвращает причину и уточнение
+// или пустые строки.
+func DetectSkip(file string, head []byte) (string, string) {
+	file = filepath.ToSlash(file)
+	name := path.Base(file)
+
+	if lockfiles[name] {
+		return SkipLockfile, name
+	}
+	if strings.Contains(name, ".min.") || strings.Contains(name, "-min.") {
+		return SkipMinified, name
+	}
+	if dir := VendoredDir(file); dir != "" {
+		return SkipVendored, dir + "/"
+	}
+
+	if len(head) > sniffSize {
+		head = head[:sniffSize]
+	}
+	if len(head) == 0 {
+		return "", ""
+	}
+
+	if isBinary(head) {
+		return SkipBinary, ""
+	}
+	if marker := goGeneratedPattern.Find(head); marker != nil {
+		return SkipGenerated, string(marker)
+	}
+	if marker := generatedMarkerPattern.Find(firstLines(head, 10)); marker != nil {
+		return SkipGenerated, string(marker)
+	}
+	if isMinified(head) {
+		return SkipMinified, "long lines"
+	}
+	return "", ""
+}
+
+// VendoredDir возвращает директорию стороннего кода, в которой находится файл,
+// или пустую строку
+func VendoredDir(file string) string {
+	parts := strings.Split(filepath.ToSlash(file), "/")
+	for i, part := range parts[:len(parts)-1] {
+		if vendoredDirs[part] {
+			return strings.Join(parts[:i+1], "/")
+		}
+	}
+	return ""
+}
+
+// IsVendoredDirName проверяет, является ли директория директорией стороннего кода
+func IsVendoredDirName(name string) bool {
+	return vendoredDirs[name]
+}
+
+// ReadHead читает начало файла для DetectSkip
+func ReadHead(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// isBinary проверяет начало файла на нулевые байты. Как и git, кодировку не
+// проверяет: исходники в CP1251 и других однобайтовых кодировках не бинарные.
+func isBinary(head []byte) bool {
+	return bytes.IndexByte(head, 0) >= 0
+}
+
+// isMinified проверяет, похоже ли содержимое на минифицированный код: очень
+// длинные строки при большой средней длине строки
+func isMinified(head []byte) bool {
+	lines := bytes.Split(head, []byte("\n"))
+	longest := 0
+	for _, line := range lines {
+		if len(line) > longest {
+			longest = len(line)
+		}
+	}
+	return longest > 1000 && len(head)/len(lines) > 300
+}
+
+// firstLines возвращает первые count строк содержимого
+func firstLines(content []byte, count int) []byte {
+	end := 0
+	for i := 0; i < count && end < len(content); i++ {
+		next := bytes.IndexByte(content[end:], '\n')
+		if next < 0 {
+			return content
+		}
+		end += next + 1
+	}
+	return content[:end]
+}
+
+// sizeUnits множители единиц размера
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"KB":  1 << 10,
+	"KIB": 1 << 10,
+	"M":   1 << 20,
+	"MB":  1 << 20,
+	"MIB": 1 << 20,
+	"G":   1 << 30,
+	"GB":  1 << 30,
+	"GIB": 1 << 30,
+}
+
+// ParseSize разбирает размер в человекочитаемом формате: "1MB", "512 KB",
+// "1.5GiB", "2048". Единицы кратны 1024. Пустая строка и "0" - без ограничения.
+func ParseSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	split := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := value, ""
+	if split >= 0 {
+		number, unit = value[:split], strings.ToUpper(strings.TrimSpace(value[split:]))
+	}
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("неизвестная единица размера %q в %q (B, KB, MB, GB)", unit, value)
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("некорректный размер %q", value)
+	}
+	return int64(amount * float64(multiplier)), nil
+}
+
+// FormatSize форматирует размер в байтах для вывода
+func FormatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", size)
+}
@@ -0,0 +1,210 @@
+package fixer
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/chunker"
+	"miniReviewer/internal/ollama"
+	"miniReviewer/internal/types"
+)
+
+// maxRegionLines максимальный размер участка, отправляемого модели для исправления
+const maxRegionLines = 80
+
+// Fixer генератор исправлений на основе найденных проблем
+type Fixer struct {
+	ollamaClient *ollama.Client
+	contextLines int
+}
+
+// NewFixer создает новый генератор исправлений.
+// contextLines задает количество строк вокруг проблемной строки, которые может заменить модель.
+func NewFixer(contextLines int) *Fixer {
+	if contextLines <= 0 {
+		contextLines = 5
+	}
+	return &Fixer{
+		ollamaClient: ollama.NewClient(),
+		contextLines: contextLines,
+	}
+}
+
+// ProposeFix запрашивает у модели замену участка файла, исправляющую проблему
+func (f *Fixer) ProposeFix(file string, content []byte, issue types.Issue) (*Patch, error) {
+	lines := splitLines(string(content))
+	start, end, err := f.selectRegion(file, content, lines, issue.Line)
+	if err != nil {
+		return nil, err
+	}
+
+	original := append([]string(nil), lines[start-1:end]...)
+	response, err := f.ollamaClient.Generate(f.buildPrompt(file, issue, original, start))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка AI-запроса исправления: %v", err)
+	}
+
+	replacement, ok := extractCodeBlock(response)
+	if !ok {
+		return nil, fmt.Errorf("модель не вернула блок кода с исправлением")
+	}
+
+	return &Patch{
+		File:        file,
+		StartLine:   start,
+		EndLine:     end,
+		Original:    original,
+		Replacement: splitLines(replacement),
+		Explanation: strings.TrimSpace(removeCodeBlocks(response)),
+		IssueLine:   issue.Line,
+		Message:     issue.Message,
+	}, nil
+}
+
+// selectRegion выбирает участок файла для исправления: для Go файлов - объявление,
+// содержащее строку проблемы (если оно не слишком большое), иначе - окрестность строки
+func (f *Fixer) selectRegion(file string, content []byte, lines []string, line int) (int, int, error) {
+	if len(lines) == 0 {
+		return 0, 0, fmt.Errorf("файл %s пуст", file)
+	}
+
+	if line <= 0 || line > len(lines) {
+		if len(lines) <= maxRegionLines {
+			return 1, len(lines), nil
+		}
+		return 0, 0, fmt.Errorf("у проблемы нет корректного номера строки")
+	}
+
+	if strings.HasSuffix(file, ".go") {
+		if chunks, err := chunker.SplitGoFile(file, content); err == nil {
+			for _, chunk := range chunks {
+				if line >= chunk.StartLine && line <= chunk.EndLine && chunk.LineCount() <= maxRegionLines {
+					return chunk.StartLine, chunk.EndLine, nil
+				}
+			}
+		}
+	}
+
+	start := line - f.contextLines
+	if start < 1 {
+		start = 1
+	}
+	end := line + f.contextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return start, end, nil
+}
+
+// buildPrompt строит промпт для генерации исправления
+func (f *Fixer) buildPrompt(file string, issue types.Issue, region []string, start int) string {
+	var numbered strings.Builder
+	for i, line := range region {
+		numbered.WriteString(fmt.Sprintf("%5d | %s\n", start+i, line))
+	}
+
+	return fmt.Sprintf(`Ты - опытный разработчик. Исправь проблему в участке кода файла %s.
+
+ПРОБЛЕМА (строка %d, важность %s):
+%s
+
+ПРЕДЛОЖЕНИЕ РЕВЬЮЕРА:
+%s
+
+УЧАСТОК КОДА (строки %d-%d, слева номера строк, они не являются частью кода):
+%s
+ВАЖНО:
+- Верни ПОЛНУЮ замену всего участка (строки %d-%d) в одном блоке кода`+"```"+`
+- Не добавляй номера строк в ответ
+- Сохрани отступы, стиль и поведение кода, не связанное с проблемой
+- Не меняй код за пределами участка
+- После блока кода кратко (1-2 предложения) объясни исправление`,
+		file, issue.Line, issue.Severity, issue.Message, issue.Suggestion,
+		start, start+len(region)-1, numbered.String(), start, start+len(region)-1)
+}
+
+var codeBlockPattern = regexp.MustCompile("(?s)```[a-zA-Z0-9_+-]*\n(.*?)```")
+
+// extractCodeBlock извлекает первый блок кода из ответа модели
+func extractCodeBlock(response string) (string, bool) {
+	match := codeBlockPattern.FindStringSubmatch(response)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// removeCodeBlocks удаляет блоки кода из ответа, оставляя пояснения
+func removeCodeBlocks(response string) string {
+	return codeBlockPattern.ReplaceAllString(response, "")
+}
+
+// ApplyPatches применяет патчи к одному файлу. Перед записью проверяется, что
+// файл не изменился с момента анализа (если известен expectedHash) и что
+// заменяемые строки совпадают с исходными. Go файлы после записи проверяются
+// go/parser и при синтаксической ошибке откатываются.
+func ApplyPatches(file string, patches []*Patch, expectedHash string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	original, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if expectedHash != "" && analyzer.ContentHash(original) != expectedHash {
+		return fmt.Errorf("файл %s изменился с момента анализа", file)
+	}
+
+	lines := splitLines(string(original))
+
+	// Применяем с конца файла, чтобы не сдвигать номера строк остальных патчей
+	sorted := append([]*Patch(nil), patches...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartLine > sorted[j].StartLine })
+
+	for _, patch := range sorted {
+		if patch.EndLine > len(lines) {
+			return fmt.Errorf("патч для строк %d-%d выходит за пределы файла", patch.StartLine, patch.EndLine)
+		}
+		current := lines[patch.StartLine-1 : patch.EndLine]
+		for i := range current {
+			if current[i] != patch.Original[i] {
+				return fmt.Errorf("строки %d-%d файла %s не совпадают с исходными", patch.StartLine, patch.EndLine, file)
+			}
+		}
+
+		updated := append([]string{}, lines[:patch.StartLine-1]...)
+		updated = append(updated, patch.Replacement...)
+		updated = append(updated, lines[patch.EndLine:]...)
+		lines = updated
+	}
+
+	patched := joinLines(lines, strings.HasSuffix(string(original), "\n"))
+	if err := os.WriteFile(file, []byte(patched), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(file, ".go") {
+		if _, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.AllErrors); err != nil {
+			if restoreErr := os.WriteFile(file, original, info.Mode().Perm()); restoreErr != nil {
+				return fmt.Errorf("синтаксическая ошибка после исправления (%v), откат не удался: %v", err, restoreErr)
+			}
+			return fmt.Errorf("синтаксическая ошибка после исправления, изменения откатаны: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// FileLines возвращает строки файла для построения diff
+func FileLines(content []byte) []string {
+	return splitLines(string(content))
+}
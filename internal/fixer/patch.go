@@ -0,0 +1,103 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Patch замена участка файла, предложенная для исправления проблемы
+type Patch struct {
+	File        string   `json:"file"`
+	StartLine   int      `json:"start_line"` // первая заменяемая строка (с 1)
+	EndLine     int      `json:"end_line"`   // последняя заменяемая строка включительно
+	Original    []string `json:"original"`
+	Replacement []string `json:"replacement"`
+	Explanation string   `json:"explanation,omitempty"`
+	IssueLine   int      `json:"issue_line,omitempty"`
+	Message     string   `json:"message,omitempty"`
+}
+
+// Overlaps проверяет, пересекаются ли заменяемые участки двух патчей
+func (p *Patch) Overlaps(other *Patch) bool {
+	return p.File == other.File && p.StartLine <= other.EndLine && other.StartLine <= p.EndLine
+}
+
+// IsNoop проверяет, что патч ничего не меняет
+func (p *Patch) IsNoop() bool {
+	if len(p.Original) != len(p.Replacement) {
+		return false
+	}
+	for i := range p.Original {
+		if p.Original[i] != p.Replacement[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UnifiedDiff строит unified diff патча относительно строк файла
+func (p *Patch) UnifiedDiff(fileLines []string, contextLines int) string {
+	before := p.StartLine - 1 - contextLines
+	if before < 0 {
+		before = 0
+	}
+	after := p.EndLine + contextLines
+	if after > len(fileLines) {
+		after = len(fileLines)
+	}
+
+	leading := fileLines[before : p.StartLine-1]
+	trailing := fileLines[p.EndLine:after]
+
+	oldCount := len(leading) + len(p.Original) + len(trailing)
+	newCount := len(leading) + len(p.Replacement) + len(trailing)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("--- a/%s\n", p.File))
+	b.WriteString(fmt.Sprintf("+++ b/%s\n", p.File))
+	b.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(before+1, oldCount), hunkRange(before+1, newCount)))
+
+	for _, line := range leading {
+		b.WriteString(" " + line + "\n")
+	}
+	for _, line := range p.Original {
+		b.WriteString("-" + line + "\n")
+	}
+	for _, line := range p.Replacement {
+		b.WriteString("+" + line + "\n")
+	}
+	for _, line := range trailing {
+		b.WriteString(" " + line + "\n")
+	}
+
+	return b.String()
+}
+
+// hunkRange форматирует диапазон строк заголовка hunk
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines разбивает содержимое файла на строки без завершающего перевода строки
+func splitLines(content string) []string {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return []string{}
+	}
+	return strings.Split(content, "\n")
+}
+
+// joinLines собирает строки обратно в содержимое файла
+func joinLines(lines []string, trailingNewline bool) string {
+	content := strings.Join(lines, "\n")
+	if trailingNewline && len(lines) > 0 {
+		content += "\n"
+	}
+	return content
+}
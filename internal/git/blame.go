@@ -0,0 +1,116 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uncommittedHash хеш, которым git blame помечает незакоммиченные строки
+const uncommittedHash = "0000000000000000000000000000000000000000"
+
+// BlameLine автор последнего изменения строки файла
+type BlameLine struct {
+	Commit  string // пустой для незакоммиченных строк
+	Author  string
+	Email   string
+	Date    time.Time
+	Summary string
+}
+
+// IndexRevision ревизия для Blame, обозначающая версию файла в индексе
+const IndexRevision = ":"
+
+// Blame возвращает авторов строк файла (ключ - номер строки с 1). revision пустой
+// для рабочей копии, IndexRevision - для версии из индекса, номера строк которой
+// отличаются от рабочей копии при частично подготовленных изменениях.
+// Незакоммиченные строки получают пустой Commit.
+func (c *Client) Blame(revision, path string) (map[int]BlameLine, error) {
+	args := []string{"blame", "--porcelain"}
+	var contents []byte
+	switch revision {
+	case "":
+	case IndexRevision:
+		// Строки индекса сопоставляются с историей через --contents
+		indexed, err := c.GetFileContent("", path)
+		if err != nil {
+			return nil, err
+		}
+		contents = []byte(indexed)
+		args = append(args, "--contents", "-")
+	default:
+		args = append(args, revision)
+	}
+	args = append(args, "--", path)
+
+	cmd := c.command(args...)
+	if contents != nil {
+		cmd.Stdin = bytes.NewReader(contents)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения git blame для %s: %v", path, err)
+	}
+
+	return parseBlamePorcelain(string(output)), nil
+}
+
+// parseBlamePorcelain разбирает вывод git blame --porcelain. Сведения о коммите
+// выводятся только при первом упоминании хеша, поэтому они кэшируются.
+func parseBlamePorcelain(output string) map[int]BlameLine {
+	lines := make(map[int]BlameLine)
+	commits := make(map[string]*BlameLine)
+
+	var current *BlameLine
+	finalLine := 0
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			// Содержимое строки завершает ее описание
+			if current != nil && finalLine > 0 {
+				lines[finalLine] = *current
+			}
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || len(fields[0]) != 40 {
+				continue
+			}
+
+			hash := fields[0]
+			finalLine, _ = strconv.Atoi(fields[2])
+
+			info, exists := commits[hash]
+			if !exists {
+				info = &BlameLine{}
+				if hash != uncommittedHash {
+					info.Commit = hash
+				}
+				commits[hash] = info
+			}
+			current = info
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Date = time.Unix(seconds, 0)
+			}
+		case "summary":
+			current.Summary = value
+		}
+	}
+
+	return lines
+}
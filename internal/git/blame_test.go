@@ -0,0 +1,56 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readBlameFixture разбирает вывод git blame --porcelain из testdata
+func readBlameFixture(t *testing.T, name string) map[int]BlameLine {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parseBlamePorcelain(string(content))
+}
+
+func TestParseBlamePorcelainRepeatedHash(t *testing.T) {
+	lines := readBlameFixture(t, "blame-repeated.txt")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want 5", len(lines))
+	}
+
+	initial := BlameLine{Commit: "8ea0cf7426e54cfcedf0c4ddd4a8e23fecc3e252", Author: "a", Email: "a@b", Date: time.Unix(1792355166, 0), Summary: "init"}
+	later := BlameLine{Commit: "433f67d270bfa1b88523d32b4b2a6156f3c9ceee", Author: "a", Email: "a@b", Date: time.Unix(1792355203, 0), Summary: "three"}
+	want := map[int]BlameLine{1: initial, 2: later, 3: initial, 5: later}
+	for line, expected := range want {
+		if got := lines[line]; got != expected {
+			t.Errorf("line %d: got %+v, want %+v", line, got, expected)
+		}
+	}
+
+	uncommitted := lines[4]
+	if uncommitted.Commit != "" || uncommitted.Author != "Not Committed Yet" {
+		t.Errorf("line 4: got %+v, want uncommitted line without commit", uncommitted)
+	}
+}
+
+func TestParseBlamePorcelainGroup(t *testing.T) {
+	lines := readBlameFixture(t, "blame-group.txt")
+
+	want := map[int]string{1: "add grp", 2: "insert line", 3: "add grp", 4: "add grp"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(want))
+	}
+	for line, summary := range want {
+		if got := lines[line].Summary; got != summary {
+			t.Errorf("line %d: got summary %q, want %q", line, got, summary)
+		}
+	}
+	if lines[4].Commit != lines[1].Commit {
+		t.Errorf("line 4: got commit %s, want %s", lines[4].Commit, lines[1].Commit)
+	}
+}
@@ -3,20 +3,51 @@ package git
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
-// Client клиент для работы с Git
-type Client struct{}
+// Client клиент для работы с Git. Команды git выполняются в корне репозитория.
+type Client struct {
+	root string
+}
+
+// NewClient создает Git клиент для репозитория, содержащего dir (пустая строка -
+// текущая директория). Вне репозитория команды выполняются в самой dir.
+func NewClient(dir string) *Client {
+	root, err := FindRoot(dir)
+	if err != nil {
+		root = dir
+	}
+	return &Client{root: root}
+}
 
-// NewClient создает новый Git клиент
-func NewClient() *Client {
-	return &Client{}
+// FindRoot возвращает корень репозитория, содержащего dir
+func FindRoot(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git репозиторий не найден в %s: %v", dir, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Root возвращает корень репозитория
+func (c *Client) Root() string {
+	return c.root
+}
+
+// command создает команду git, выполняемую в корне репозитория
+func (c *Client) command(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = c.root
+	return cmd
 }
 
 // IsRepository проверяет, является ли директория Git репозиторием
 func (c *Client) IsRepository() bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
+	cmd := c.command("rev-parse", "--git-dir")
 	return cmd.Run() == nil
 }
 
@@ -25,11 +56,11 @@ func (c *Client) GetDiff(from, to string) (string, error) {
 	var cmd *exec.Cmd
 	
 	if from != "" && to != "" {
-		cmd = exec.Command("git", "diff", from, to)
+		cmd = c.command("diff", from, to)
 	} else if from != "" {
-		cmd = exec.Command("git", "diff", from)
+		cmd = c.command("diff", from)
 	} else {
-		cmd = exec.Command("git", "diff", "HEAD")
+		cmd = c.command("diff", "HEAD")
 	}
 
 	output, err := cmd.Output()
@@ -42,7 +73,7 @@ func (c *Client) GetDiff(from, to string) (string, error) {
 
 // GetStatus получает статус git репозитория
 func (c *Client) GetStatus() (string, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
+	cmd := c.command("status", "--porcelain")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения git status: %v", err)
@@ -53,7 +84,7 @@ func (c *Client) GetStatus() (string, error) {
 
 // GetCurrentBranch получает текущую ветку
 func (c *Client) GetCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "branch", "--show-current")
+	cmd := c.command("branch", "--show-current")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения текущей ветки: %v", err)
@@ -62,28 +93,84 @@ func (c *Client) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetCommitHistory получает историю коммитов
-func (c *Client) GetCommitHistory(limit int) ([]string, error) {
-	cmd := exec.Command("git", "log", "--oneline", fmt.Sprintf("-%d", limit))
+// GetCommitHistory получает коммиты диапазона (по умолчанию текущей ветки) без
+// merge-коммитов в хронологическом порядке: от старых к новым. limit оставляет
+// последние limit коммитов.
+func (c *Client) GetCommitHistory(revRange string, limit int) ([]Commit, error) {
+	if revRange == "" {
+		revRange = "HEAD"
+	}
+
+	commits, err := c.GetCommits(revRange, limit, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// GetCommits получает коммиты диапазона (например "main..HEAD") со статистикой
+// изменений по файлам. limit <= 0 снимает ограничение, skipMerges исключает merge-коммиты.
+func (c *Client) GetCommits(revRange string, limit int, skipMerges bool) ([]Commit, error) {
+	args := []string{"log", "--numstat", "--format=" + commitLogFormat}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", limit))
+	}
+	if skipMerges {
+		args = append(args, "--no-merges")
+	}
+	if revRange != "" {
+		args = append(args, revRange)
+	}
+
+	cmd := c.command(args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения истории коммитов: %v", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var commits []string
-	for _, line := range lines {
-		if line != "" {
-			commits = append(commits, line)
-		}
+	return parseCommitLog(string(output)), nil
+}
+
+// GetCommitsSince получает коммиты текущей ветки без merge-коммитов за период
+// (since в формате git, например "6 months ago") со статистикой изменений
+func (c *Client) GetCommitsSince(since string) ([]Commit, error) {
+	args := []string{"log", "--numstat", "--no-merges", "--format=" + commitLogFormat}
+	if since != "" {
+		args = append(args, "--since="+since)
 	}
 
-	return commits, nil
+	cmd := c.command(args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения истории коммитов: %v", err)
+	}
+
+	return parseCommitLog(string(output)), nil
+}
+
+// GetCommitDiff получает изменения, внесенные коммитом (для merge-коммитов - относительно первого родителя)
+func (c *Client) GetCommitDiff(hash string) (string, error) {
+	cmd := c.command("show", "--format=", "--first-parent", "--patch", hash)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("ошибка получения изменений коммита %s: %v", hash, err)
+	}
+	return string(output), nil
+}
+
+// IsAncestor проверяет, входит ли коммит в историю ref (например, уже влит в основную ветку)
+func (c *Client) IsAncestor(commit, ref string) bool {
+	cmd := c.command("merge-base", "--is-ancestor", commit, ref)
+	return cmd.Run() == nil
 }
 
 // GetLastCommit получает хеш последнего коммита
 func (c *Client) GetLastCommit() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd := c.command("rev-parse", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения последнего коммита: %v", err)
@@ -96,11 +183,11 @@ func (c *Client) GetChangedFiles(from, to string) ([]string, error) {
 	var cmd *exec.Cmd
 	
 	if from != "" && to != "" {
-		cmd = exec.Command("git", "diff", "--name-only", from, to)
+		cmd = c.command("diff", "--name-only", from, to)
 	} else if from != "" {
-		cmd = exec.Command("git", "diff", "--name-only", from)
+		cmd = c.command("diff", "--name-only", from)
 	} else {
-		cmd = exec.Command("git", "diff", "--name-only", "HEAD")
+		cmd = c.command("diff", "--name-only", "HEAD")
 	}
 
 	output, err := cmd.Output()
@@ -121,7 +208,7 @@ func (c *Client) GetChangedFiles(from, to string) ([]string, error) {
 
 // GetFileContent получает содержимое файла на определенном коммите
 func (c *Client) GetFileContent(commit, filepath string) (string, error) {
-	cmd := exec.Command("git", "show", fmt.Sprintf("%s:%s", commit, filepath))
+	cmd := c.command("show", fmt.Sprintf("%s:%s", commit, filepath))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения содержимого файла %s на коммите %s: %v", filepath, commit, err)
@@ -132,7 +219,7 @@ func (c *Client) GetFileContent(commit, filepath string) (string, error) {
 
 // GetUnstagedDiff получает diff незакоммиченных изменений
 func (c *Client) GetUnstagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff")
+	cmd := c.command("diff")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения unstaged diff: %v", err)
@@ -142,7 +229,7 @@ func (c *Client) GetUnstagedDiff() (string, error) {
 
 // GetStagedDiff получает diff подготовленных к коммиту изменений
 func (c *Client) GetStagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached")
+	cmd := c.command("diff", "--cached")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("ошибка получения staged diff: %v", err)
@@ -150,20 +237,52 @@ func (c *Client) GetStagedDiff() (string, error) {
 	return string(output), nil
 }
 
-// GetMainBranch получает основную ветку (main или master)
+// GetMainBranch получает основную ветку: ветку по умолчанию удаленного репозитория
+// (refs/remotes/origin/HEAD), затем локальные main или master, затем origin/main
+// или origin/master. Если ни одна не найдена, возвращает пустую строку.
 func (c *Client) GetMainBranch() string {
-	// Сначала пробуем main
-	cmd := exec.Command("git", "rev-parse", "--verify", "main")
-	if cmd.Run() == nil {
-		return "main"
+	cmd := c.command("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if output, err := cmd.Output(); err == nil {
+		if branch := strings.TrimSpace(string(output)); branch != "" {
+			return branch
+		}
 	}
-	
-	// Если main не существует, пробуем master
-	cmd = exec.Command("git", "rev-parse", "--verify", "master")
-	if cmd.Run() == nil {
-		return "master"
+
+	for _, branch := range []string{"main", "master", "origin/main", "origin/master"} {
+		if c.RefExists(branch) {
+			return branch
+		}
 	}
-	
-	// Если ни одна не существует, возвращаем пустую строку
+
 	return ""
 }
+
+// RefExists проверяет, указывает ли ссылка (ветка, тег, хеш) на коммит
+func (c *Client) RefExists(ref string) bool {
+	cmd := c.command("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return cmd.Run() == nil
+}
+
+// GetMergeBase получает общего предка двух ревизий, от которого ветвится MR
+func (c *Client) GetMergeBase(a, b string) (string, error) {
+	cmd := c.command("merge-base", a, b)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("ошибка получения merge-base для %s и %s: %v", a, b, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetHooksDir возвращает директорию хуков репозитория с учетом core.hooksPath
+func (c *Client) GetHooksDir() (string, error) {
+	output, err := c.command("rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("ошибка получения директории хуков: %v", err)
+	}
+
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.root, dir)
+	}
+	return dir, nil
+}
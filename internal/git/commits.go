@@ -0,0 +1,130 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Разделители полей в выводе git log: \x1e начинает запись коммита,
+// \x1f разделяет поля, \x1d отделяет сообщение от статистики --numstat
+const commitLogFormat = "%x1e%H%x1f%h%x1f%an%x1f%ae%x1f%aI%x1f%P%x1f%s%x1f%b%x1d"
+
+// Commit коммит с сообщением и статистикой изменений
+type Commit struct {
+	Hash      string     `json:"hash"`
+	ShortHash string     `json:"short_hash"`
+	Author    string     `json:"author"`
+	Email     string     `json:"email"`
+	Date      time.Time  `json:"date"`
+	Parents   []string   `json:"parents,omitempty"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body,omitempty"`
+	Files     []FileStat `json:"files,omitempty"`
+}
+
+// FileStat количество добавленных и удаленных строк в файле
+type FileStat struct {
+	Path      string `json:"path"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary,omitempty"`
+	OldPath   string `json:"old_path,omitempty"` // путь до переименования
+}
+
+// IsMerge проверяет, является ли коммит merge-коммитом
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Message возвращает полное сообщение коммита
+func (c Commit) Message() string {
+	if c.Body == "" {
+		return c.Subject
+	}
+	return c.Subject + "\n\n" + c.Body
+}
+
+// ChangedLines возвращает общее количество добавленных и удаленных строк
+func (c Commit) ChangedLines() int {
+	total := 0
+	for _, file := range c.Files {
+		total += file.Additions + file.Deletions
+	}
+	return total
+}
+
+// parseCommitLog разбирает вывод git log в формате commitLogFormat с --numstat
+func parseCommitLog(output string) []Commit {
+	var commits []Commit
+
+	for _, record := range strings.Split(output, "\x1e") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		header, stats, _ := strings.Cut(record, "\x1d")
+		fields := strings.SplitN(header, "\x1f", 8)
+		if len(fields) < 8 {
+			continue
+		}
+
+		commit := Commit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Author:    fields[2],
+			Email:     fields[3],
+			Parents:   strings.Fields(fields[5]),
+			Subject:   strings.TrimSpace(fields[6]),
+			Body:      strings.TrimSpace(fields[7]),
+		}
+		commit.Date, _ = time.Parse(time.RFC3339, fields[4])
+		commit.Files = parseNumstat(stats)
+
+		commits = append(commits, commit)
+	}
+
+	return commits
+}
+
+// parseNumstat разбирает строки "добавлено<TAB>удалено<TAB>путь"; для бинарных файлов git выводит "-"
+func parseNumstat(stats string) []FileStat {
+	var files []FileStat
+	for _, line := range strings.Split(stats, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		oldPath, newPath := splitRename(parts[2])
+		file := FileStat{Path: newPath, OldPath: oldPath}
+		if parts[0] == "-" && parts[1] == "-" {
+			file.Binary = true
+		} else {
+			file.Additions, _ = strconv.Atoi(parts[0])
+			file.Deletions, _ = strconv.Atoi(parts[1])
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// splitRename возвращает старый и новый путь переименованного файла: numstat
+// выводит переименования как "old => new" или "dir/{old => new}/file".
+// Для обычного файла старый путь пустой.
+func splitRename(path string) (string, string) {
+	if open := strings.Index(path, "{"); open >= 0 {
+		if end := strings.Index(path[open:], "}"); end > 0 {
+			inner := path[open+1 : open+end]
+			if oldPart, newPart, found := strings.Cut(inner, " => "); found {
+				prefix, suffix := path[:open], path[open+end+1:]
+				clean := func(p string) string { return strings.ReplaceAll(p, "//", "/") }
+				return clean(prefix + oldPart + suffix), clean(prefix + newPart + suffix)
+			}
+		}
+	}
+	if oldPath, newPath, found := strings.Cut(path, " => "); found {
+		return oldPath, newPath
+	}
+	return "", path
+}
@@ -0,0 +1,314 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// DiffLine строка hunk с номерами в старой и новой версии файла (0 - строки нет)
+type DiffLine struct {
+	Kind    byte // '+' добавлена, '-' удалена, ' ' контекст
+	Content string
+	OldLine int
+	NewLine int
+}
+
+// Hunk фрагмент изменений файла
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Section  string // текст после @@ (обычно объявление, в котором находится hunk)
+	Lines    []DiffLine
+}
+
+// ChangeKind вид изменения файла по заголовкам diff
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeDeleted  ChangeKind = "deleted"
+	ChangeModified ChangeKind = "modified"
+	ChangeRenamed  ChangeKind = "renamed"
+	ChangeCopied   ChangeKind = "copied"
+)
+
+// FileDiff изменения одного файла
+type FileDiff struct {
+	OldPath    string // "" для нового файла
+	NewPath    string // "" для удаленного файла
+	OldMode    string // режим файла до изменения, если он указан в заголовке
+	NewMode    string // режим файла после изменения, если он указан в заголовке
+	Copied     bool   // файл скопирован из OldPath
+	Similarity int    // сходство с исходным файлом при переименовании или копировании, %
+	Binary     bool
+	Hunks      []Hunk
+}
+
+// Path возвращает путь файла в новой версии, а для удаленного файла - в старой
+func (f FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// IsDeleted проверяет, удален ли файл
+func (f FileDiff) IsDeleted() bool {
+	return f.NewPath == ""
+}
+
+// Kind возвращает вид изменения файла
+func (f FileDiff) Kind() ChangeKind {
+	switch {
+	case f.OldPath == "":
+		return ChangeAdded
+	case f.NewPath == "":
+		return ChangeDeleted
+	case f.Copied:
+		return ChangeCopied
+	case f.OldPath != f.NewPath:
+		return ChangeRenamed
+	}
+	return ChangeModified
+}
+
+// ModeChanged проверяет, изменен ли режим файла (например, добавлен флаг исполнения)
+func (f FileDiff) ModeChanged() bool {
+	return f.OldMode != "" && f.NewMode != "" && f.OldMode != f.NewMode
+}
+
+// Summary возвращает краткое описание изменения по заголовкам diff:
+// переименование, копирование, смену режима, удаление, бинарный файл
+func (f FileDiff) Summary() string {
+	var parts []string
+	switch f.Kind() {
+	case ChangeAdded:
+		parts = append(parts, "новый файл")
+	case ChangeDeleted:
+		parts = append(parts, "файл удален")
+	case ChangeRenamed:
+		parts = append(parts, fmt.Sprintf("переименован из %s%s", f.OldPath, f.similarityNote()))
+	case ChangeCopied:
+		parts = append(parts, fmt.Sprintf("скопирован из %s%s", f.OldPath, f.similarityNote()))
+	}
+	if f.ModeChanged() {
+		parts = append(parts, fmt.Sprintf("режим %s → %s", f.OldMode, f.NewMode))
+	}
+	if f.Binary {
+		parts = append(parts, "бинарный файл")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// similarityNote возвращает сходство с исходным файлом для описания
+func (f FileDiff) similarityNote() string {
+	if f.Similarity == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (сходство %d%%)", f.Similarity)
+}
+
+// AddedLines возвращает номера добавленных строк в новой версии файла
+func (f FileDiff) AddedLines() []int {
+	var lines []int
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Kind == '+' {
+				lines = append(lines, line.NewLine)
+			}
+		}
+	}
+	return lines
+}
+
+// MapLine приводит номер строки, названный моделью, к строке нового файла,
+// попадающей в diff. Строки вне hunk переносятся на ближайшую строку hunk,
+// чтобы замечание можно было привязать к изменению в code review.
+func (f FileDiff) MapLine(line int) int {
+	if line <= 0 {
+		return 0
+	}
+
+	best, bestDistance := 0, -1
+	for _, hunk := range f.Hunks {
+		for _, l := range hunk.Lines {
+			if l.NewLine == 0 {
+				continue
+			}
+			distance := l.NewLine - line
+			if distance < 0 {
+				distance = -distance
+			}
+			if distance == 0 {
+				return line
+			}
+			if bestDistance < 0 || distance < bestDistance {
+				best, bestDistance = l.NewLine, distance
+			}
+		}
+	}
+	return best
+}
+
+// Annotated возвращает изменения файла для промпта: номер строки нового файла
+// слева, удаленные строки без номера
+func (f FileDiff) Annotated() string {
+	var builder strings.Builder
+	for _, hunk := range f.Hunks {
+		if hunk.NewLines == 0 {
+			builder.WriteString(fmt.Sprintf("@@ удаление после строки %d @@ %s\n", hunk.NewStart, hunk.Section))
+		} else {
+			builder.WriteString(fmt.Sprintf("@@ строки %d-%d @@ %s\n", hunk.NewStart, hunk.NewStart+hunk.NewLines-1, hunk.Section))
+		}
+		for _, line := range hunk.Lines {
+			number := "    "
+			if line.NewLine > 0 {
+				number = fmt.Sprintf("%4d", line.NewLine)
+			}
+			builder.WriteString(fmt.Sprintf("%s %c %s\n", number, line.Kind, line.Content))
+		}
+	}
+	return builder.String()
+}
+
+// ParseDiff разбирает вывод git diff в unified формате на изменения по файлам
+func ParseDiff(diff string) []FileDiff {
+	var files []FileDiff
+	var current *FileDiff
+	var hunk *Hunk
+	oldLine, newLine := 0, 0
+
+	flushHunk := func() {
+		if current != nil && hunk != nil {
+			current.Hunks = append(current.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if current != nil {
+			files = append(files, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flushFile()
+			oldPath, newPath := parseDiffGitHeader(line)
+			current = &FileDiff{OldPath: oldPath, NewPath: newPath}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if hunk != nil {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: '+', Content: line[1:], NewLine: newLine})
+				newLine++
+				continue
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: '-', Content: line[1:], OldLine: oldLine})
+				oldLine++
+				continue
+			case strings.HasPrefix(line, " "):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: ' ', Content: line[1:], OldLine: oldLine, NewLine: newLine})
+				oldLine++
+				newLine++
+				continue
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+				continue
+			}
+		}
+
+		if match := hunkHeaderPattern.FindStringSubmatch(line); match != nil {
+			flushHunk()
+			hunk = &Hunk{
+				OldStart: atoiDefault(match[1], 0),
+				OldLines: atoiDefault(match[2], 1),
+				NewStart: atoiDefault(match[3], 0),
+				NewLines: atoiDefault(match[4], 1),
+				Section:  match[5],
+			}
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			continue
+		}
+
+		// Расширенные заголовки git: новый и удаленный файл, режим, переименование
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			current.OldPath = diffPath(strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			current.NewPath = diffPath(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+			current.Binary = true
+		case strings.HasPrefix(line, "new file mode "):
+			current.OldPath = ""
+			current.NewMode = strings.TrimPrefix(line, "new file mode ")
+		case strings.HasPrefix(line, "deleted file mode "):
+			current.NewPath = ""
+			current.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+		case strings.HasPrefix(line, "old mode "):
+			current.OldMode = strings.TrimPrefix(line, "old mode ")
+		case strings.HasPrefix(line, "new mode "):
+			current.NewMode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "rename from "):
+			current.OldPath = diffPath(strings.TrimPrefix(line, "rename from "), "")
+		case strings.HasPrefix(line, "rename to "):
+			current.NewPath = diffPath(strings.TrimPrefix(line, "rename to "), "")
+		case strings.HasPrefix(line, "copy from "):
+			current.OldPath = diffPath(strings.TrimPrefix(line, "copy from "), "")
+			current.Copied = true
+		case strings.HasPrefix(line, "copy to "):
+			current.NewPath = diffPath(strings.TrimPrefix(line, "copy to "), "")
+		case strings.HasPrefix(line, "similarity index "):
+			current.Similarity = atoiDefault(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"), 0)
+		}
+	}
+	flushFile()
+
+	return files
+}
+
+// parseDiffGitHeader извлекает пути из строки "diff --git a/old b/new". Точные пути
+// уточняются строками ---/+++, если они есть.
+func parseDiffGitHeader(line string) (string, string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if index := strings.Index(rest, " b/"); strings.HasPrefix(rest, "a/") && index > 0 {
+		return rest[2:index], rest[index+3:]
+	}
+	return "", ""
+}
+
+// diffPath убирает префикс a/ или b/; /dev/null означает отсутствие файла
+func diffPath(path, prefix string) string {
+	path = strings.TrimSuffix(path, "\t")
+	if path == "/dev/null" {
+		return ""
+	}
+	if unquoted, err := strconv.Unquote(path); err == nil {
+		path = unquoted
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// atoiDefault преобразует строку в число или возвращает значение по умолчанию
+func atoiDefault(value string, fallback int) int {
+	if value == "" {
+		return fallback
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return fallback
+	}
+	return number
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readDiffFixture разбирает diff из testdata
+func readDiffFixture(t *testing.T, name string) []FileDiff {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ParseDiff(string(content))
+}
+
+func TestParseDiffRename(t *testing.T) {
+	files := readDiffFixture(t, "rename.diff")
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+
+	edited := files[0]
+	if edited.OldPath != "old.go" || edited.NewPath != "new.go" || edited.Kind() != ChangeRenamed {
+		t.Errorf("got %s -> %s (%s), want old.go -> new.go (renamed)", edited.OldPath, edited.NewPath, edited.Kind())
+	}
+	if edited.Similarity != 85 {
+		t.Errorf("got similarity %d, want 85", edited.Similarity)
+	}
+	if got := edited.Summary(); got != "переименован из old.go (сходство 85%)" {
+		t.Errorf("got summary %q", got)
+	}
+	if got := edited.AddedLines(); !reflect.DeepEqual(got, []int{8}) {
+		t.Errorf("got added lines %v, want [8]", got)
+	}
+	for line, want := range map[int]int{8: 8, 6: 6, 1: 5, 100: 9, 0: 0} {
+		if got := edited.MapLine(line); got != want {
+			t.Errorf("MapLine(%d): got %d, want %d", line, got, want)
+		}
+	}
+
+	pure := files[1]
+	if pure.OldPath != "trim.txt" || pure.NewPath != "kept.txt" || pure.Similarity != 100 {
+		t.Errorf("got %s -> %s (%d%%), want trim.txt -> kept.txt (100%%)", pure.OldPath, pure.NewPath, pure.Similarity)
+	}
+	if len(pure.Hunks) != 0 || pure.MapLine(3) != 0 {
+		t.Errorf("got %d hunks, MapLine(3) = %d, want no hunks and 0", len(pure.Hunks), pure.MapLine(3))
+	}
+}
+
+func TestParseDiffBinary(t *testing.T) {
+	files := readDiffFixture(t, "binary.diff")
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+
+	file := files[0]
+	if !file.Binary || file.Path() != "logo.png" || file.Kind() != ChangeModified {
+		t.Errorf("got binary=%v path=%q kind=%s, want binary logo.png modified", file.Binary, file.Path(), file.Kind())
+	}
+	if len(file.Hunks) != 0 {
+		t.Errorf("got %d hunks, want 0", len(file.Hunks))
+	}
+	if got := file.Summary(); got != "бинарный файл" {
+		t.Errorf("got summary %q, want %q", got, "бинарный файл")
+	}
+}
+
+func TestParseDiffModeOnly(t *testing.T) {
+	files := readDiffFixture(t, "mode.diff")
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+
+	file := files[0]
+	if file.Path() != "run.sh" || file.Kind() != ChangeModified {
+		t.Errorf("got %q (%s), want run.sh (modified)", file.Path(), file.Kind())
+	}
+	if !file.ModeChanged() || file.OldMode != "100644" || file.NewMode != "100755" {
+		t.Errorf("got mode %s -> %s, want 100644 -> 100755", file.OldMode, file.NewMode)
+	}
+	if got := file.Summary(); got != "режим 100644 → 100755" {
+		t.Errorf("got summary %q", got)
+	}
+	if len(file.Hunks) != 0 || len(file.AddedLines()) != 0 {
+		t.Errorf("got %d hunks, want 0", len(file.Hunks))
+	}
+}
+
+func TestParseDiffDeletionOnly(t *testing.T) {
+	files := readDiffFixture(t, "deletion.diff")
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+
+	deleted := files[0]
+	if !deleted.IsDeleted() || deleted.Path() != "gone.txt" || deleted.Kind() != ChangeDeleted || deleted.OldMode != "100644" {
+		t.Errorf("got deleted=%v path=%q kind=%s mode=%q, want deleted gone.txt", deleted.IsDeleted(), deleted.Path(), deleted.Kind(), deleted.OldMode)
+	}
+	if len(deleted.Hunks) != 1 || deleted.Hunks[0].NewLines != 0 || len(deleted.Hunks[0].Lines) != 3 {
+		t.Fatalf("got hunks %+v, want one hunk with 3 removed lines", deleted.Hunks)
+	}
+	if deleted.MapLine(1) != 0 {
+		t.Errorf("MapLine(1): got %d, want 0", deleted.MapLine(1))
+	}
+
+	trimmed := files[1]
+	if len(trimmed.Hunks) != 1 {
+		t.Fatalf("got %d hunks, want 1", len(trimmed.Hunks))
+	}
+	hunk := trimmed.Hunks[0]
+	if hunk.OldStart != 1 || hunk.OldLines != 5 || hunk.NewStart != 1 || hunk.NewLines != 3 {
+		t.Errorf("got hunk -%d,%d +%d,%d, want -1,5 +1,3", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines)
+	}
+	want := []DiffLine{
+		{Kind: ' ', Content: "a", OldLine: 1, NewLine: 1},
+		{Kind: '-', Content: "b", OldLine: 2},
+		{Kind: '-', Content: "c", OldLine: 3},
+		{Kind: ' ', Content: "d", OldLine: 4, NewLine: 2},
+		{Kind: ' ', Content: "e", OldLine: 5, NewLine: 3},
+	}
+	if !reflect.DeepEqual(hunk.Lines, want) {
+		t.Errorf("got lines %+v, want %+v", hunk.Lines, want)
+	}
+	if got := trimmed.AddedLines(); len(got) != 0 {
+		t.Errorf("got added lines %v, want none", got)
+	}
+	if got := trimmed.MapLine(2); got != 2 {
+		t.Errorf("MapLine(2): got %d, want 2", got)
+	}
+}
+
+func TestParseDiffNoNewlineAtEOF(t *testing.T) {
+	files := readDiffFixture(t, "noeol.diff")
+	if len(files) != 1 || len(files[0].Hunks) != 1 {
+		t.Fatalf("got %+v, want one file with one hunk", files)
+	}
+
+	want := []DiffLine{
+		{Kind: ' ', Content: "one", OldLine: 1, NewLine: 1},
+		{Kind: ' ', Content: "two", OldLine: 2, NewLine: 2},
+		{Kind: '-', Content: "three", OldLine: 3},
+		{Kind: '+', Content: "THREE", NewLine: 3},
+	}
+	if got := files[0].Hunks[0].Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("got lines %+v, want %+v", got, want)
+	}
+	if got := files[0].MapLine(5); got != 3 {
+		t.Errorf("MapLine(5): got %d, want 3", got)
+	}
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// emptyTreeHash хеш пустого дерева git, с которым сравнивается индекс до первого коммита
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// WorkingTreeOptions категории изменений рабочей копии, попадающие в diff
+type WorkingTreeOptions struct {
+	Staged    bool // изменения в индексе
+	Unstaged  bool // изменения рабочей копии, не добавленные в индекс
+	Untracked bool // новые файлы, не отслеживаемые git (с учетом .gitignore)
+}
+
+// GetWorkingTreeDiff возвращает объединенный diff выбранных категорий изменений рабочей
+// копии. Неотслеживаемые файлы показываются как полностью добавленные.
+func (c *Client) GetWorkingTreeDiff(options WorkingTreeOptions) (string, error) {
+	var builder strings.Builder
+
+	var args []string
+	switch {
+	case options.Staged && options.Unstaged:
+		args = []string{"diff", c.headOrEmptyTree()}
+	case options.Staged:
+		args = []string{"diff", "--cached", c.headOrEmptyTree()}
+	case options.Unstaged:
+		args = []string{"diff"}
+	}
+
+	if args != nil {
+		output, err := c.command(args...).Output()
+		if err != nil {
+			return "", fmt.Errorf("ошибка получения diff рабочей копии: %v", err)
+		}
+		builder.Write(output)
+	}
+
+	if options.Untracked {
+		files, err := c.GetUntrackedFiles()
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			diff, err := c.getNewFileDiff(file)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(diff)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// GetUntrackedFiles возвращает неотслеживаемые файлы, не исключенные .gitignore
+func (c *Client) GetUntrackedFiles() ([]string, error) {
+	output, err := c.command("ls-files", "--others", "--exclude-standard", "-z").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения неотслеживаемых файлов: %v", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// getNewFileDiff возвращает diff неотслеживаемого файла как полностью добавленного
+func (c *Client) getNewFileDiff(path string) (string, error) {
+	output, err := c.command("diff", "--no-index", "--", "/dev/null", path).Output()
+	if err != nil {
+		// С --no-index git возвращает код 1, если файлы различаются
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return "", fmt.Errorf("ошибка получения diff для %s: %v", path, err)
+		}
+	}
+	return string(output), nil
+}
+
+// headOrEmptyTree возвращает HEAD или пустое дерево, если коммитов еще нет
+func (c *Client) headOrEmptyTree() string {
+	if c.command("rev-parse", "--verify", "--quiet", "HEAD").Run() == nil {
+		return "HEAD"
+	}
+	return emptyTreeHash
+}
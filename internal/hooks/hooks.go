@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Status состояние хука в директории хуков
+type Status struct {
+	Kind      string
+	Path      string
+	Installed bool // установлен скрипт miniReviewer
+	Foreign   bool // установлен другой хук
+	Chained   bool // существующий хук сохранен и вызывается перед проверкой
+}
+
+// ChainedPath возвращает путь, по которому сохраняется существующий хук
+func (s Status) ChainedPath() string {
+	return s.Path + chainedSuffix
+}
+
+// Install записывает скрипт хука в dir. Существующий чужой хук сохраняется
+// с суффиксом .pre-miniReviewer и вызывается из нового скрипта. Повторная
+// установка перезаписывает скрипт miniReviewer.
+func Install(dir, kind string, options Options) (Status, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return Status{}, fmt.Errorf("ошибка создания директории хуков %s: %v", dir, err)
+	}
+
+	status := GetStatus(dir, kind)
+	if status.Foreign {
+		chained := status.ChainedPath()
+		if _, err := os.Stat(chained); err == nil {
+			return status, fmt.Errorf("хук %s уже сохранен в %s, удалите один из них вручную", kind, chained)
+		}
+		if err := os.Rename(status.Path, chained); err != nil {
+			return status, fmt.Errorf("ошибка сохранения существующего хука %s: %v", kind, err)
+		}
+	}
+
+	if err := os.WriteFile(status.Path, []byte(Script(kind, options)), 0755); err != nil {
+		return status, fmt.Errorf("ошибка записи хука %s: %v", kind, err)
+	}
+
+	return GetStatus(dir, kind), nil
+}
+
+// Uninstall удаляет скрипт miniReviewer и восстанавливает сохраненный хук.
+// Чужие хуки не изменяются.
+func Uninstall(dir, kind string) (Status, error) {
+	status := GetStatus(dir, kind)
+	if status.Foreign {
+		return status, fmt.Errorf("хук %s установлен не miniReviewer, оставлен без изменений", kind)
+	}
+	if !status.Installed {
+		return status, nil
+	}
+
+	if err := os.Remove(status.Path); err != nil {
+		return status, fmt.Errorf("ошибка удаления хука %s: %v", kind, err)
+	}
+	if status.Chained {
+		if err := os.Rename(status.ChainedPath(), status.Path); err != nil {
+			return status, fmt.Errorf("ошибка восстановления хука %s: %v", kind, err)
+		}
+	}
+
+	return GetStatus(dir, kind), nil
+}
+
+// GetStatus возвращает состояние хука
+func GetStatus(dir, kind string) Status {
+	status := Status{Kind: kind, Path: filepath.Join(dir, kind)}
+
+	if content, err := os.ReadFile(status.Path); err == nil {
+		if strings.Contains(string(content), marker) {
+			status.Installed = true
+		} else {
+			status.Foreign = true
+		}
+	}
+
+	if _, err := os.Stat(status.ChainedPath()); err == nil {
+		status.Chained = true
+	}
+
+	return status
+}
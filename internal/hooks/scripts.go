@@ -0,0 +1,160 @@
+package hooks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Поддерживаемые git хуки
+const (
+	PreCommit        = "pre-commit"
+	PrePush          = "pre-push"
+	PrepareCommitMsg = "prepare-commit-msg"
+)
+
+// SkipEnv переменная окружения, отключающая проверку в хуках
+const SkipEnv = "MINIREVIEWER_SKIP"
+
+// marker отличает скрипты miniReviewer от других хуков
+const marker = "# miniReviewer hook"
+
+// chainedSuffix суффикс, с которым сохраняется существующий хук
+const chainedSuffix = ".pre-miniReviewer"
+
+// Options параметры проверки, записываемые в скрипт хука
+type Options struct {
+	Binary     string // путь к исполняемому файлу miniReviewer
+	FailOn     string // минимальная важность проблем, блокирующая коммит или push
+	TimeBudget string // время анализа, после которого проверка пропускается
+}
+
+// Kinds возвращает поддерживаемые хуки
+func Kinds() []string {
+	return []string{PreCommit, PrePush, PrepareCommitMsg}
+}
+
+// ValidateKind проверяет имя хука
+func ValidateKind(kind string) error {
+	for _, known := range Kinds() {
+		if kind == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("неподдерживаемый хук %q (допустимо: %s)", kind, strings.Join(Kinds(), ", "))
+}
+
+// Script возвращает текст скрипта хука. Сохраненный хук запускается первым
+// с теми же аргументами и stdin.
+func Script(kind string, options Options) string {
+	analyze := fmt.Sprintf("%s analyze --fail-on %s --time-budget %s",
+		shellQuote(options.Binary), shellQuote(options.FailOn), shellQuote(options.TimeBudget))
+	describe := fmt.Sprintf("%s describe --staged", shellQuote(options.Binary))
+
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString(fmt.Sprintf("%s (%s), установлен командой miniReviewer hook install\n", marker, kind))
+	script.WriteString(fmt.Sprintf("# Пропустить проверку: %s=1 git ...\n\n", SkipEnv))
+	script.WriteString("hook_dir=$(dirname \"$0\")\n")
+	script.WriteString(fmt.Sprintf("chained=\"$hook_dir/%s%s\"\n", kind, chainedSuffix))
+
+	switch kind {
+	case PreCommit:
+		script.WriteString(fmt.Sprintf(preCommitTemplate, SkipEnv, SkipEnv, analyze))
+	case PrePush:
+		script.WriteString(fmt.Sprintf(prePushTemplate, SkipEnv, SkipEnv, analyze))
+	case PrepareCommitMsg:
+		script.WriteString(fmt.Sprintf(prepareCommitMsgTemplate, SkipEnv, SkipEnv, describe))
+	}
+
+	return script.String()
+}
+
+// preCommitTemplate проверяет подготовленные к коммиту изменения
+const preCommitTemplate = `
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+if [ -n "$%s" ]; then
+	echo "miniReviewer: проверка пропущена (%s)"
+	exit 0
+fi
+
+exec %s --staged
+`
+
+// prePushTemplate проверяет отправляемые коммиты каждой ссылки. Для новой ветки
+// анализируются изменения от ответвления от ветки по умолчанию удаленного репозитория.
+const prePushTemplate = `
+input=$(cat)
+
+if [ -x "$chained" ]; then
+	printf '%%s\n' "$input" | "$chained" "$@" || exit $?
+fi
+
+if [ -n "$%s" ]; then
+	echo "miniReviewer: проверка пропущена (%s)"
+	exit 0
+fi
+
+is_zero() {
+	case "$1" in
+	*[!0]*) return 1 ;;
+	esac
+	return 0
+}
+
+printf '%%s\n' "$input" | {
+	status=0
+	while read -r local_ref local_sha remote_ref remote_sha; do
+		# Пустая строка или удаление ветки
+		if [ -z "$local_sha" ] || is_zero "$local_sha"; then
+			continue
+		fi
+
+		base=""
+		if ! is_zero "$remote_sha" && git cat-file -e "$remote_sha^{commit}" 2>/dev/null; then
+			base="$remote_sha"
+		elif git rev-parse --verify -q "refs/remotes/$1/HEAD" >/dev/null; then
+			base=$(git merge-base "refs/remotes/$1/HEAD" "$local_sha" 2>/dev/null)
+		fi
+		if [ -z "$base" ]; then
+			echo "miniReviewer: не удалось определить отправляемые изменения $local_ref, проверка пропущена"
+			continue
+		fi
+		if [ "$base" = "$local_sha" ]; then
+			continue
+		fi
+
+		echo "miniReviewer: проверка $local_ref ($base..$local_sha)"
+		%s --from "$base" --to "$local_sha" </dev/null || status=1
+	done
+	exit $status
+}
+`
+
+// prepareCommitMsgTemplate записывает сгенерированное сообщение, если оно не задано
+// другим способом (-m, -F, merge, squash, amend). Ошибка генерации не прерывает коммит.
+const prepareCommitMsgTemplate = `
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+
+if [ -n "$%s" ]; then
+	echo "miniReviewer: генерация сообщения пропущена (%s)"
+	exit 0
+fi
+
+case "$2" in
+message|merge|squash|commit) exit 0 ;;
+esac
+
+%s --write-commit-msg "$1" </dev/null ||
+	echo "miniReviewer: не удалось сгенерировать сообщение коммита"
+exit 0
+`
+
+// shellQuote экранирует значение для sh
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
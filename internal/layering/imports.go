@@ -0,0 +1,216 @@
+package layering
+
+import (
+	"bufio"
+	"bytes"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportRef импорт, найденный в исходном файле
+type ImportRef struct {
+	Raw    string // импорт в том виде, в котором он записан в коде
+	Target string // путь цели относительно корня проекта или исходный импорт для внешних зависимостей
+	Local  bool   // импорт указывает на код внутри проекта
+	Line   int    // строка объявления импорта
+}
+
+var (
+	jsImportPattern  = regexp.MustCompile(`(?:^|[^\w.$])(?:import|export)\s+(?:[\w*${}\s,]+\s+from\s+)?['"]([^'"]+)['"]`)
+	jsKeywordPattern = regexp.MustCompile(`\b(?:import|export|require)\b`)
+	jsRequirePattern = regexp.MustCompile(`(?:^|[^\w.$])(?:require|import)\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+	pyImportPattern  = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	pyFromPattern    = regexp.MustCompile(`^\s*from\s+(\.*)([\w.]*)\s+import\s+`)
+)
+
+// ExtractImports находит импорты в файле. file - путь относительно корня проекта,
+// module - путь Go модуля (пустой, если проект не является Go модулем).
+// Неподдерживаемые языки возвращают пустой список.
+func ExtractImports(file string, content []byte, module string) []ImportRef {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".go":
+		return extractGoImports(file, content, module)
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs":
+		return extractJSImports(file, content)
+	case ".py":
+		return extractPythonImports(file, content)
+	default:
+		return nil
+	}
+}
+
+// extractGoImports разбирает объявления импортов Go файла с помощью go/parser
+func extractGoImports(file string, content []byte, module string) []ImportRef {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, content, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+
+	var imports []ImportRef
+	for _, spec := range parsed.Imports {
+		raw, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		ref := ImportRef{Raw: raw, Target: raw, Line: fset.Position(spec.Pos()).Line}
+		if module != "" && (raw == module || strings.HasPrefix(raw, module+"/")) {
+			ref.Local = true
+			ref.Target = strings.TrimPrefix(strings.TrimPrefix(raw, module), "/")
+			if ref.Target == "" {
+				ref.Target = "."
+			}
+		}
+		imports = append(imports, ref)
+	}
+	return imports
+}
+
+// jsSourceExtensions расширения исходных файлов, которые отбрасываются у
+// относительных импортов; остальные (например, ".service" в "./user.service")
+// являются частью имени файла
+var jsSourceExtensions = map[string]bool{
+	".js": true, ".jsx": true, ".ts": true, ".tsx": true, ".mjs": true, ".cjs": true,
+}
+
+// extractJSImports находит import/export ... from и require() в JavaScript/TypeScript.
+// Поиск идет по всему содержимому, поэтому находятся и многострочные импорты;
+// строка импорта - строка ключевого слова. Относительные импорты разрешаются в
+// путь без расширения относительно корня проекта.
+func extractJSImports(file string, content []byte) []ImportRef {
+	var imports []ImportRef
+	dir := path.Dir(filepath.ToSlash(file))
+	code := blankJSComments(content)
+
+	var matches [][]int
+	matches = append(matches, jsImportPattern.FindAllSubmatchIndex(code, -1)...)
+	matches = append(matches, jsRequirePattern.FindAllSubmatchIndex(code, -1)...)
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i][0] < matches[j][0]
+	})
+
+	for _, match := range matches {
+		// Строка импорта - строка последнего ключевого слова перед путем: совпадение
+		// может захватить предыдущую инструкцию без точки с запятой
+		start := match[0]
+		if keywords := jsKeywordPattern.FindAllIndex(code[match[0]:match[2]], -1); len(keywords) > 0 {
+			start += keywords[len(keywords)-1][0]
+		}
+
+		raw := string(code[match[2]:match[3]])
+		ref := ImportRef{Raw: raw, Target: raw, Line: bytes.Count(code[:start], []byte("\n")) + 1}
+		if strings.HasPrefix(raw, "./") || strings.HasPrefix(raw, "../") {
+			ref.Local = true
+			target := path.Join(dir, raw)
+			if jsSourceExtensions[path.Ext(target)] {
+				target = strings.TrimSuffix(target, path.Ext(target))
+			}
+			ref.Target = target
+		}
+		imports = append(imports, ref)
+	}
+
+	return imports
+}
+
+// blankJSComments заменяет комментарии JavaScript/TypeScript пробелами, сохраняя
+// переводы строк и смещения. Строковые литералы не изменяются.
+func blankJSComments(content []byte) []byte {
+	code := append([]byte(nil), content...)
+	for i := 0; i < len(code); i++ {
+		switch code[i] {
+		case '\'', '"', '`':
+			quote := code[i]
+			// Обычные строки не переносятся, поэтому апостроф в тексте не скрывает код ниже
+			for i++; i < len(code) && code[i] != quote && (quote == '`' || code[i] != '\n'); i++ {
+				if code[i] == '\\' {
+					i++
+				}
+			}
+		case '/':
+			if i+1 >= len(code) {
+				continue
+			}
+			switch code[i+1] {
+			case '/':
+				for ; i < len(code) && code[i] != '\n'; i++ {
+					code[i] = ' '
+				}
+			case '*':
+				code[i], code[i+1] = ' ', ' '
+				for i += 2; i < len(code) && !(code[i] == '*' && i+1 < len(code) && code[i+1] == '/'); i++ {
+					if code[i] != '\n' {
+						code[i] = ' '
+					}
+				}
+				if i+1 < len(code) {
+					code[i], code[i+1] = ' ', ' '
+					i++
+				}
+			}
+		}
+	}
+	return code
+}
+
+// extractPythonImports находит import и from ... import в Python.
+// Модули переводятся в пути (a.b.c -> a/b/c), относительные импорты разрешаются от пакета файла.
+func extractPythonImports(file string, content []byte) []ImportRef {
+	var imports []ImportRef
+	dir := path.Dir(filepath.ToSlash(file))
+
+	forEachLine(content, func(number int, line string) {
+		if match := pyFromPattern.FindStringSubmatch(line); match != nil {
+			dots, module := match[1], match[2]
+			ref := ImportRef{Raw: dots + module, Line: number}
+			if dots != "" {
+				base := dir
+				for i := 1; i < len(dots); i++ {
+					base = path.Dir(base)
+				}
+				ref.Local = true
+				ref.Target = path.Join(base, strings.ReplaceAll(module, ".", "/"))
+			} else {
+				ref.Target = strings.ReplaceAll(module, ".", "/")
+			}
+			imports = append(imports, ref)
+			return
+		}
+
+		if match := pyImportPattern.FindStringSubmatch(line); match != nil {
+			for _, part := range strings.Split(match[1], ",") {
+				fields := strings.Fields(part)
+				if len(fields) == 0 {
+					continue
+				}
+				module := fields[0]
+				imports = append(imports, ImportRef{
+					Raw:    module,
+					Target: strings.ReplaceAll(module, ".", "/"),
+					Line:   number,
+				})
+			}
+		}
+	})
+
+	return imports
+}
+
+// forEachLine вызывает fn для каждой строки содержимого с номером, начиная с 1
+func forEachLine(content []byte, fn func(number int, line string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	number := 0
+	for scanner.Scan() {
+		number++
+		fn(number, scanner.Text())
+	}
+}
@@ -0,0 +1,37 @@
+package layering
+
+import "testing"
+
+func TestExtractJSImports(t *testing.T) {
+	content := []byte(`// import { Old } from '../legacy'
+import React from 'react'
+import {
+  UserService,
+  OrderService,
+} from '../domain/services'
+/* import x from './commented'
+   still a comment */
+import { User } from './user.service'
+const helper = require('./helper.js')
+const url = 'http://example.com' // import y from './y'
+export * from "./index.ts"
+`)
+
+	want := []ImportRef{
+		{Raw: "react", Target: "react", Line: 2},
+		{Raw: "../domain/services", Target: "src/domain/services", Local: true, Line: 3},
+		{Raw: "./user.service", Target: "src/app/user.service", Local: true, Line: 9},
+		{Raw: "./helper.js", Target: "src/app/helper", Local: true, Line: 10},
+		{Raw: "./index.ts", Target: "src/app/index", Local: true, Line: 12},
+	}
+
+	got := ExtractImports("src/app/main.ts", content, "")
+	if len(got) != len(want) {
+		t.Fatalf("got %d imports, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("import %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
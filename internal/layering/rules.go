@@ -0,0 +1,219 @@
+package layering
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+// Rule правило зависимостей между слоями проекта.
+// From, Allow и Deny - шаблоны путей относительно корня проекта: "*" совпадает
+// с одним сегментом пути, "**" - с любым количеством сегментов. Шаблон совпадает
+// с каталогом и всеми его подкаталогами, поэтому "internal" покрывает "internal/types".
+type Rule struct {
+	From        string   `mapstructure:"from"`        // слой, к файлам которого применяется правило
+	Allow       []string `mapstructure:"allow"`       // разрешенные импорты (исключения из deny)
+	Deny        []string `mapstructure:"deny"`        // запрещенные импорты
+	Description string   `mapstructure:"description"` // пояснение правила для отчета
+}
+
+// Violation нарушение правила зависимостей
+type Violation struct {
+	File   string    // файл относительно корня проекта
+	Line   int       // строка импорта
+	Import ImportRef // нарушающий импорт
+	Rule   Rule      // нарушенное правило
+}
+
+// Checker проверяет импорты файлов проекта на соответствие правилам
+type Checker struct {
+	root   string
+	module string
+	rules  []Rule
+}
+
+// NewChecker создает проверку правил для проекта с корнем root.
+// module - путь Go модуля, нужен для распознавания локальных импортов Go.
+func NewChecker(root, module string, rules []Rule) *Checker {
+	return &Checker{
+		root:   root,
+		module: module,
+		rules:  rules,
+	}
+}
+
+// Validate проверяет корректность правил
+func (c *Checker) Validate() error {
+	for i, rule := range c.rules {
+		if strings.TrimSpace(rule.From) == "" {
+			return fmt.Errorf("правило %d: не указан слой from", i+1)
+		}
+		if len(rule.Allow) == 0 && len(rule.Deny) == 0 {
+			return fmt.Errorf("правило %d (%s): нужно указать allow или deny", i+1, rule.From)
+		}
+	}
+	return nil
+}
+
+// CheckFile проверяет импорты одного файла. Пути файлов берутся относительно корня проекта.
+func (c *Checker) CheckFile(file string, content []byte) []Violation {
+	rel, err := filepath.Rel(c.root, file)
+	if err != nil {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	dir := path.Dir(rel)
+
+	var applicable []Rule
+	for _, rule := range c.rules {
+		if MatchPath(rule.From, dir) {
+			applicable = append(applicable, rule)
+		}
+	}
+	if len(applicable) == 0 {
+		return nil
+	}
+
+	var violations []Violation
+	for _, ref := range ExtractImports(rel, content, c.module) {
+		if !ref.Local && !c.existsInProject(ref.Target) {
+			continue
+		}
+
+		for _, rule := range applicable {
+			if violates(rule, ref.Target) {
+				violations = append(violations, Violation{
+					File:   rel,
+					Line:   ref.Line,
+					Import: ref,
+					Rule:   rule,
+				})
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// violates проверяет, нарушает ли импорт правило. Если deny не задан,
+// allow работает как белый список: разрешены только перечисленные слои и сам слой from.
+func violates(rule Rule, target string) bool {
+	for _, pattern := range rule.Allow {
+		if MatchPath(pattern, target) {
+			return false
+		}
+	}
+
+	if len(rule.Deny) == 0 {
+		return !MatchPath(rule.From, target)
+	}
+
+	for _, pattern := range rule.Deny {
+		if MatchPath(pattern, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// existsInProject проверяет, указывает ли импорт (например, Python модуль) на код проекта
+func (c *Checker) existsInProject(target string) bool {
+	if target == "" || strings.HasPrefix(target, "/") {
+		return false
+	}
+
+	candidate := filepath.Join(c.root, filepath.FromSlash(target))
+	if _, err := os.Stat(candidate); err == nil {
+		return true
+	}
+	if _, err := os.Stat(candidate + ".py"); err == nil {
+		return true
+	}
+	return false
+}
+
+// MatchPath проверяет, совпадает ли путь или один из его родительских каталогов с шаблоном
+func MatchPath(pattern, target string) bool {
+	pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+	target = strings.Trim(target, "/")
+
+	if pattern == "" || pattern == "." {
+		return true
+	}
+
+	patternParts := strings.Split(pattern, "/")
+	targetParts := strings.Split(target, "/")
+
+	for i := len(targetParts); i > 0; i-- {
+		if matchSegments(patternParts, targetParts[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments сопоставляет сегменты пути с сегментами шаблона, поддерживая "**"
+func matchSegments(pattern, target []string) bool {
+	if len(pattern) == 0 {
+		return len(target) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(target); i++ {
+			if matchSegments(pattern[1:], target[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(target) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], target[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], target[1:])
+}
+
+// ToIssue преобразует нарушение в архитектурную проблему важности high
+func (v Violation) ToIssue() types.Issue {
+	imported := v.Import.Raw
+	if v.Import.Local {
+		imported = v.Import.Target
+	}
+
+	message := fmt.Sprintf("Нарушено правило слоев: %s импортирует %s", path.Dir(v.File), imported)
+	if v.Rule.Description != "" {
+		message = fmt.Sprintf("%s (%s)", message, v.Rule.Description)
+	}
+
+	return types.Issue{
+		Type:       "architecture",
+		Severity:   "high",
+		Message:    message,
+		Suggestion: fmt.Sprintf("Уберите зависимость или измените правило для слоя %s в architecture.rules", v.Rule.From),
+		Reasoning:  describeRule(v.Rule),
+		File:       v.File,
+		Line:       v.Line,
+	}
+}
+
+// describeRule формирует текстовое описание правила
+func describeRule(rule Rule) string {
+	var parts []string
+	if len(rule.Deny) > 0 {
+		parts = append(parts, "запрещено: "+strings.Join(rule.Deny, ", "))
+	}
+	if len(rule.Allow) > 0 {
+		parts = append(parts, "разрешено: "+strings.Join(rule.Allow, ", "))
+	}
+	return fmt.Sprintf("Правило для %s (%s)", rule.From, strings.Join(parts, "; "))
+}
@@ -0,0 +1,236 @@
+package lintimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+// Форматы вывода линтеров
+const (
+	FormatAuto        = "auto"
+	FormatGoVet       = "govet"       // go vet -json
+	FormatStaticcheck = "staticcheck" // staticcheck -f json
+	FormatESLint      = "eslint"      // eslint -f json
+	FormatRuff        = "ruff"        // ruff check --output-format json
+	FormatCheckstyle  = "checkstyle"  // checkstyle XML (eslint, golangci-lint, ruff и другие)
+)
+
+// Formats поддерживаемые форматы (без auto)
+var Formats = []string{FormatGoVet, FormatStaticcheck, FormatESLint, FormatRuff, FormatCheckstyle}
+
+// Finding замечание линтера
+type Finding struct {
+	Tool     string // линтер: go vet, staticcheck, eslint, ruff
+	Rule     string // код правила: SA4006, no-unused-vars, F401, printf
+	File     string
+	Line     int
+	Column   int
+	Severity string // важность в терминах линтера: error, warning, info
+	Message  string
+	Fix      string // описание автоисправления, если линтер его предлагает
+}
+
+// ValidateFormat проверяет название формата
+func ValidateFormat(format string) error {
+	if format == FormatAuto {
+		return nil
+	}
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("неизвестный формат вывода линтера: %s (поддерживаются: %s, %s)", format, FormatAuto, strings.Join(Formats, ", "))
+}
+
+// ParseFile читает файл с выводом линтера. Непустой tool переопределяет имя линтера,
+// например для checkstyle вывода golangci-lint.
+func ParseFile(path, format, tool string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := Parse(data, format, tool)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return findings, nil
+}
+
+// Parse разбирает вывод линтера в указанном формате (auto - определить по содержимому)
+func Parse(data []byte, format, tool string) ([]Finding, error) {
+	if format == "" || format == FormatAuto {
+		format = DetectFormat(data)
+		if format == "" {
+			return nil, fmt.Errorf("не удалось определить формат вывода линтера, укажите --format")
+		}
+	}
+
+	var findings []Finding
+	var err error
+	switch format {
+	case FormatGoVet:
+		findings, err = parseGoVet(data)
+	case FormatStaticcheck:
+		findings, err = parseStaticcheck(data)
+	case FormatESLint:
+		findings, err = parseESLint(data)
+	case FormatRuff:
+		findings, err = parseRuff(data)
+	case FormatCheckstyle:
+		findings, err = parseCheckstyle(data)
+	default:
+		return nil, ValidateFormat(format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разбора вывода %s: %v", format, err)
+	}
+
+	if tool != "" {
+		for i := range findings {
+			findings[i].Tool = tool
+		}
+	}
+	return findings, nil
+}
+
+// DetectFormat определяет формат по содержимому: XML - checkstyle, массив JSON -
+// eslint или ruff, поток JSON объектов - staticcheck или go vet
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch trimmed[0] {
+	case '<':
+		return FormatCheckstyle
+	case '[':
+		var entries []map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return ""
+		}
+		if len(entries) == 0 {
+			return FormatESLint
+		}
+		if _, ok := entries[0]["filePath"]; ok {
+			return FormatESLint
+		}
+		if _, ok := entries[0]["filename"]; ok {
+			return FormatRuff
+		}
+	case '#':
+		return FormatGoVet
+	case '{':
+		var first map[string]json.RawMessage
+		if err := json.NewDecoder(bytes.NewReader(trimmed)).Decode(&first); err != nil {
+			return ""
+		}
+		_, hasCode := first["code"]
+		_, hasLocation := first["location"]
+		if hasCode && hasLocation {
+			return FormatStaticcheck
+		}
+		return FormatGoVet
+	}
+
+	return ""
+}
+
+// ToIssue преобразует замечание линтера в проблему. Путь файла приводится к
+// пути относительно base, если файл находится внутри base.
+func (f Finding) ToIssue(base string) types.Issue {
+	message := f.Message
+	if f.Rule != "" {
+		message = fmt.Sprintf("%s (%s)", f.Message, f.Rule)
+	}
+
+	suggestion := f.Fix
+	if suggestion == "" {
+		suggestion = fmt.Sprintf("Исправьте замечание %s", f.Tool)
+	}
+
+	return types.Issue{
+		Type:       issueType(f.Tool, f.Rule),
+		Severity:   normalizeSeverity(f.Severity),
+		Message:    message,
+		Suggestion: suggestion,
+		Line:       f.Line,
+		Column:     f.Column,
+		File:       RelativePath(base, f.File),
+		Rule:       f.Rule,
+		Categories: []string{f.Tool},
+	}
+}
+
+// RelativePath возвращает путь файла относительно base, если он внутри base
+func RelativePath(base, file string) string {
+	if !filepath.IsAbs(file) || base == "" {
+		return filepath.Clean(file)
+	}
+
+	absoluteBase, err := filepath.Abs(base)
+	if err != nil {
+		return file
+	}
+
+	relative, err := filepath.Rel(absoluteBase, file)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return file
+	}
+	return relative
+}
+
+// normalizeSeverity переводит важность линтера в уровни miniReviewer
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error", "fatal":
+		return "high"
+	case "warning", "warn":
+		return "medium"
+	case "info", "note", "hint", "ignored":
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+// issueType определяет тип проблемы по линтеру и буквенному префиксу правила
+func issueType(tool, rule string) string {
+	prefix := strings.TrimRightFunc(rule, func(r rune) bool { return r >= '0' && r <= '9' })
+
+	switch tool {
+	case "go vet":
+		return "bug"
+	case "staticcheck":
+		switch prefix {
+		case "SA":
+			return "bug"
+		case "S", "ST", "QF":
+			return "style"
+		}
+	case "ruff":
+		switch prefix {
+		case "S":
+			return "security"
+		case "F", "B":
+			return "bug"
+		case "E", "W", "I", "N", "D", "UP", "SIM":
+			return "style"
+		case "PERF":
+			return "performance"
+		}
+	case "eslint":
+		if strings.Contains(rule, "security") {
+			return "security"
+		}
+	}
+	return "quality"
+}
@@ -0,0 +1,281 @@
+package lintimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseGoVet разбирает вывод go vet -json: поток объектов
+// {"пакет": {"анализатор": [{"posn": "file:line:col", "message": "..."}]}},
+// перемежающийся строками-комментариями "# пакет"
+func parseGoVet(data []byte) ([]Finding, error) {
+	var cleaned bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "#") {
+			continue
+		}
+		cleaned.Write(scanner.Bytes())
+		cleaned.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	type diagnostic struct {
+		Posn           string `json:"posn"`
+		Message        string `json:"message"`
+		SuggestedFixes []struct {
+			Message string `json:"message"`
+		} `json:"suggested_fixes"`
+	}
+
+	var findings []Finding
+	decoder := json.NewDecoder(&cleaned)
+	for {
+		var packages map[string]map[string]json.RawMessage
+		if err := decoder.Decode(&packages); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, analyzers := range packages {
+			for name, raw := range analyzers {
+				// Ошибка анализатора приходит объектом {"error": "..."} - это не замечание
+				var diagnostics []diagnostic
+				if json.Unmarshal(raw, &diagnostics) != nil {
+					continue
+				}
+
+				for _, d := range diagnostics {
+					file, line, column := splitPosition(d.Posn)
+					finding := Finding{
+						Tool:     "go vet",
+						Rule:     name,
+						File:     file,
+						Line:     line,
+						Column:   column,
+						Severity: "warning",
+						Message:  d.Message,
+					}
+					if len(d.SuggestedFixes) > 0 {
+						finding.Fix = d.SuggestedFixes[0].Message
+					}
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// parseStaticcheck разбирает вывод staticcheck -f json (один объект на строку).
+// staticcheck помечает все замечания как error, поэтому высокая важность
+// остается только у проверок SA (вероятные ошибки).
+func parseStaticcheck(data []byte) ([]Finding, error) {
+	type position struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+	type diagnostic struct {
+		Code     string   `json:"code"`
+		Severity string   `json:"severity"`
+		Location position `json:"location"`
+		Message  string   `json:"message"`
+	}
+
+	var findings []Finding
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var d diagnostic
+		if err := decoder.Decode(&d); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if d.Severity == "ignored" {
+			continue
+		}
+
+		severity := "warning"
+		if strings.HasPrefix(d.Code, "SA") || d.Code == "compile" {
+			severity = "error"
+		}
+
+		findings = append(findings, Finding{
+			Tool:     "staticcheck",
+			Rule:     d.Code,
+			File:     d.Location.File,
+			Line:     d.Location.Line,
+			Column:   d.Location.Column,
+			Severity: severity,
+			Message:  d.Message,
+		})
+	}
+
+	return findings, nil
+}
+
+// parseESLint разбирает вывод eslint -f json
+func parseESLint(data []byte) ([]Finding, error) {
+	var files []struct {
+		FilePath string `json:"filePath"`
+		Messages []struct {
+			RuleID   *string `json:"ruleId"`
+			Severity int     `json:"severity"`
+			Message  string  `json:"message"`
+			Line     int     `json:"line"`
+			Column   int     `json:"column"`
+			Fix      *struct {
+				Text string `json:"text"`
+			} `json:"fix"`
+			Suggestions []struct {
+				Desc string `json:"desc"`
+			} `json:"suggestions"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		for _, message := range file.Messages {
+			finding := Finding{
+				Tool:     "eslint",
+				File:     file.FilePath,
+				Line:     message.Line,
+				Column:   message.Column,
+				Severity: "warning",
+				Message:  message.Message,
+			}
+			if message.RuleID != nil {
+				finding.Rule = *message.RuleID
+			}
+			if message.Severity == 2 {
+				finding.Severity = "error"
+			}
+			if len(message.Suggestions) > 0 {
+				finding.Fix = message.Suggestions[0].Desc
+			} else if message.Fix != nil {
+				finding.Fix = "Доступно автоисправление: eslint --fix"
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// parseRuff разбирает вывод ruff check --output-format json. Ruff не задает
+// важность, поэтому ошибками считаются только синтаксические ошибки и pyflakes (F).
+func parseRuff(data []byte) ([]Finding, error) {
+	var diagnostics []struct {
+		Code     *string `json:"code"`
+		Message  string  `json:"message"`
+		Filename string  `json:"filename"`
+		Location struct {
+			Row    int `json:"row"`
+			Column int `json:"column"`
+		} `json:"location"`
+		Fix *struct {
+			Message string `json:"message"`
+		} `json:"fix"`
+	}
+	if err := json.Unmarshal(data, &diagnostics); err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, d := range diagnostics {
+		finding := Finding{
+			Tool:     "ruff",
+			File:     d.Filename,
+			Line:     d.Location.Row,
+			Column:   d.Location.Column,
+			Severity: "warning",
+			Message:  d.Message,
+		}
+		if d.Code == nil {
+			finding.Severity = "error"
+		} else {
+			finding.Rule = *d.Code
+			if strings.HasPrefix(finding.Rule, "F") {
+				finding.Severity = "error"
+			}
+		}
+		if d.Fix != nil {
+			finding.Fix = d.Fix.Message
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+// parseCheckstyle разбирает checkstyle XML. Линтер определяется по атрибуту
+// source ("eslint.rules.no-unused-vars"), иначе используется "checkstyle".
+func parseCheckstyle(data []byte) ([]Finding, error) {
+	var report struct {
+		Files []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line     int    `xml:"line,attr"`
+				Column   int    `xml:"column,attr"`
+				Severity string `xml:"severity,attr"`
+				Message  string `xml:"message,attr"`
+				Source   string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	for _, file := range report.Files {
+		for _, e := range file.Errors {
+			tool, rule := "checkstyle", e.Source
+			if strings.HasPrefix(rule, "eslint.rules.") {
+				tool, rule = "eslint", strings.TrimPrefix(rule, "eslint.rules.")
+			}
+
+			findings = append(findings, Finding{
+				Tool:     tool,
+				Rule:     rule,
+				File:     file.Name,
+				Line:     e.Line,
+				Column:   e.Column,
+				Severity: e.Severity,
+				Message:  e.Message,
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// splitPosition разбирает позицию вида "file.go:12:5"
+func splitPosition(posn string) (string, int, int) {
+	parts := strings.Split(posn, ":")
+	if len(parts) < 3 {
+		return posn, 0, 0
+	}
+
+	line, errLine := strconv.Atoi(parts[len(parts)-2])
+	column, errColumn := strconv.Atoi(parts[len(parts)-1])
+	if errLine != nil || errColumn != nil {
+		return posn, 0, 0
+	}
+	return strings.Join(parts[:len(parts)-2], ":"), line, column
+}
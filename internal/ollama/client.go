@@ -16,6 +16,7 @@ type Client struct {
 	host    string
 	timeout time.Duration
 	client  *http.Client
+	config  *viper.Viper
 }
 
 // Request структура для запроса к Ollama
@@ -38,27 +39,50 @@ type Response struct {
 	TotalDuration int64  `json:"total_duration"`
 }
 
+// EmbeddingRequest структура для запроса эмбеддинга к Ollama
+type EmbeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+// EmbeddingResponse структура для ответа с эмбеддингом от Ollama
+type EmbeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
 // NewClient создает новый клиент Ollama
 func NewClient() *Client {
-	timeout, _ := time.ParseDuration(viper.GetString("ollama.timeout"))
+	return NewClientWithConfig(viper.GetViper())
+}
+
+// NewClientWithConfig создает клиент Ollama с настройками ollama.* из config,
+// например с настройками модуля монорепозитория
+func NewClientWithConfig(config *viper.Viper) *Client {
+	timeout, _ := time.ParseDuration(config.GetString("ollama.timeout"))
 	if timeout == 0 {
 		timeout = 300 * time.Second
 	}
 
 	return &Client{
-		host:    viper.GetString("ollama.host"),
+		host:    config.GetString("ollama.host"),
 		timeout: timeout,
 		client: &http.Client{
 			Timeout: timeout,
 		},
+		config: config,
 	}
 }
 
+// Model возвращает модель, которой отправляются запросы
+func (c *Client) Model() string {
+	return c.config.GetString("ollama.default_model")
+}
+
 // Generate отправляет запрос к Ollama и получает ответ
 func (c *Client) Generate(prompt string) (string, error) {
-	model := viper.GetString("ollama.default_model")
-	temperature := viper.GetFloat64("ollama.temperature")
-	maxTokens := viper.GetInt("ollama.max_tokens")
+	model := c.Model()
+	temperature := c.config.GetFloat64("ollama.temperature")
+	maxTokens := c.config.GetInt("ollama.max_tokens")
 
 	request := Request{
 		Model:  model,
@@ -92,6 +116,41 @@ func (c *Client) Generate(prompt string) (string, error) {
 	return ollamaResp.Response, nil
 }
 
+// Embed получает векторное представление текста через модель эмбеддингов
+func (c *Client) Embed(text string) ([]float64, error) {
+	request := EmbeddingRequest{
+		Model:  c.config.GetString("ollama.embedding_model"),
+		Prompt: text,
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка маршалинга запроса: %v", err)
+	}
+
+	resp, err := c.client.Post(c.host+"/api/embeddings", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка запроса к Ollama: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Ollama вернул статус %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embeddingResp EmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddingResp); err != nil {
+		return nil, fmt.Errorf("ошибка декодирования ответа: %v", err)
+	}
+
+	if len(embeddingResp.Embedding) == 0 {
+		return nil, fmt.Errorf("Ollama вернул пустой эмбеддинг")
+	}
+
+	return embeddingResp.Embedding, nil
+}
+
 // HealthCheck проверяет доступность Ollama
 func (c *Client) HealthCheck() error {
 	resp, err := c.client.Get(c.host + "/api/tags")
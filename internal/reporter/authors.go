@@ -0,0 +1,76 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/types"
+)
+
+// hasAuthorInfo проверяет, определен ли автор хотя бы для одной проблемы
+func hasAuthorInfo(results []*types.CodeAnalysisResult) bool {
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if issue.Blame != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// blameLabel возвращает автора, коммит и дату строки с проблемой
+func blameLabel(blame *types.BlameInfo) string {
+	label := analyzer.AuthorLabel(blame.Author)
+	if blame.Commit == "" {
+		return label
+	}
+
+	commit := blame.Commit
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	return fmt.Sprintf("%s (%s, %s)", label, commit, blame.Date.Format("2006-01-02"))
+}
+
+// writeMarkdownAuthors выводит количество проблем по авторам строк
+func writeMarkdownAuthors(report *strings.Builder, results []*types.CodeAnalysisResult) {
+	report.WriteString("## Findings by Author\n\n")
+	report.WriteString("| Author | Issues | Critical | High | Medium | Low |\n")
+	report.WriteString("|--------|--------|----------|------|--------|-----|\n")
+
+	for _, stat := range analyzer.GroupByAuthor(results) {
+		author := analyzer.AuthorLabel(stat.Author)
+		if stat.Email != "" {
+			author += " <" + stat.Email + ">"
+		}
+		report.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d |\n",
+			escapeMarkdownCell(author), stat.Issues,
+			stat.BySeverity["critical"], stat.BySeverity["high"], stat.BySeverity["medium"], stat.BySeverity["low"]))
+	}
+	report.WriteString("\n")
+}
+
+// writeHTMLAuthors выводит количество проблем по авторам строк
+func writeHTMLAuthors(report *strings.Builder, results []*types.CodeAnalysisResult) {
+	report.WriteString(`
+        <h2>Проблемы по авторам</h2>
+        <table class="author-table">
+            <tr><th>Автор</th><th>Проблем</th><th>Критических</th><th>Высоких</th><th>Средних</th><th>Низких</th></tr>`)
+
+	for _, stat := range analyzer.GroupByAuthor(results) {
+		author := html.EscapeString(analyzer.AuthorLabel(stat.Author))
+		if stat.Email != "" {
+			author += fmt.Sprintf(` <small>%s</small>`, html.EscapeString(stat.Email))
+		}
+		report.WriteString(fmt.Sprintf(`
+            <tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>`,
+			author, stat.Issues,
+			stat.BySeverity["critical"], stat.BySeverity["high"], stat.BySeverity["medium"], stat.BySeverity["low"]))
+	}
+
+	report.WriteString(`
+        </table>`)
+}
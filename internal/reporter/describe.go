@@ -0,0 +1,74 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/types"
+)
+
+// GenerateDescriptionReport генерирует описание Pull Request по сгенерированному
+// описанию изменений
+func (r *Reporter) GenerateDescriptionReport(description *types.ChangeDescription, format string) (string, error) {
+	switch format {
+	case "json":
+		report := struct {
+			CommitMessage string `json:"commit_message"`
+			*types.ChangeDescription
+		}{analyzer.CommitMessage(description), description}
+
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("ошибка сериализации JSON: %v", err)
+		}
+		return string(data), nil
+	case "markdown":
+		return generateDescriptionMarkdown(description), nil
+	default:
+		return "", fmt.Errorf("неподдерживаемый формат описания: %s (markdown, json)", format)
+	}
+}
+
+// generateDescriptionMarkdown генерирует описание Pull Request в Markdown
+func generateDescriptionMarkdown(description *types.ChangeDescription) string {
+	var report strings.Builder
+
+	report.WriteString(fmt.Sprintf("# %s\n\n", description.Title))
+
+	report.WriteString("## Summary\n\n")
+	report.WriteString(description.Summary + "\n\n")
+
+	if description.Breaking {
+		report.WriteString("> **Breaking change:** this change is not backward compatible.\n\n")
+	}
+
+	report.WriteString("## Risk Areas\n\n")
+	writeMarkdownList(&report, description.RiskAreas, "No notable risks.")
+
+	report.WriteString("## Testing Notes\n\n")
+	writeMarkdownList(&report, description.TestingNotes, "No testing notes.")
+
+	if len(description.Commits) > 0 {
+		report.WriteString("## Commits\n\n")
+		writeMarkdownList(&report, description.Commits, "")
+	}
+
+	report.WriteString("## Commit Message\n\n")
+	report.WriteString("```\n" + analyzer.CommitMessage(description) + "\n```\n")
+
+	return report.String()
+}
+
+// writeMarkdownList выводит пункты списка или текст для пустого списка
+func writeMarkdownList(report *strings.Builder, items []string, empty string) {
+	if len(items) == 0 {
+		report.WriteString(empty + "\n\n")
+		return
+	}
+	for _, item := range items {
+		report.WriteString(fmt.Sprintf("- %s\n", item))
+	}
+	report.WriteString("\n")
+}
@@ -0,0 +1,328 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"miniReviewer/internal/types"
+
+	"github.com/spf13/viper"
+)
+
+// historySummary сводка по истории коммитов
+type historySummary struct {
+	Commits     int `json:"commits"`
+	Skipped     int `json:"skipped"`
+	AvgScore    int `json:"avg_score"`
+	TotalIssues int `json:"total_issues"`
+	Regressions int `json:"regressions"`
+}
+
+// summarizeHistory считает сводку по проанализированным коммитам
+func summarizeHistory(points []types.CommitTrendPoint) historySummary {
+	summary := historySummary{Commits: len(points)}
+	analyzed, totalScore := 0, 0
+	for _, point := range points {
+		if point.Skipped {
+			summary.Skipped++
+			continue
+		}
+		analyzed++
+		totalScore += point.Score
+		summary.TotalIssues += point.Issues
+		if point.Regression {
+			summary.Regressions++
+		}
+	}
+	if analyzed > 0 {
+		summary.AvgScore = totalScore / analyzed
+	}
+	return summary
+}
+
+// chartPoints возвращает коммиты, попадающие на график (с анализируемыми изменениями)
+func chartPoints(points []types.CommitTrendPoint) []types.CommitTrendPoint {
+	var charted []types.CommitTrendPoint
+	for _, point := range points {
+		if !point.Skipped {
+			charted = append(charted, point)
+		}
+	}
+	return charted
+}
+
+// GenerateHistoryReport генерирует отчет по истории коммитов с графиком динамики
+func (r *Reporter) GenerateHistoryReport(points []types.CommitTrendPoint, revRange, format string) (string, error) {
+	switch format {
+	case "json":
+		return generateHistoryJSON(points, revRange)
+	case "markdown":
+		return generateHistoryMarkdown(points, revRange), nil
+	default:
+		return generateHistoryHTML(points, revRange), nil
+	}
+}
+
+// generateHistoryJSON генерирует JSON отчет по истории коммитов
+func generateHistoryJSON(points []types.CommitTrendPoint, revRange string) (string, error) {
+	report := struct {
+		GeneratedAt time.Time                `json:"generated_at"`
+		Model       string                   `json:"model"`
+		Range       string                   `json:"range"`
+		Summary     historySummary           `json:"summary"`
+		Commits     []types.CommitTrendPoint `json:"commits"`
+	}{
+		GeneratedAt: time.Now(),
+		Model:       viper.GetString("ollama.default_model"),
+		Range:       revRange,
+		Summary:     summarizeHistory(points),
+		Commits:     points,
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("ошибка маршалинга JSON: %v", err)
+	}
+	return string(jsonData), nil
+}
+
+// generateHistoryMarkdown генерирует Markdown отчет с графиком Mermaid
+func generateHistoryMarkdown(points []types.CommitTrendPoint, revRange string) string {
+	var report strings.Builder
+	summary := summarizeHistory(points)
+
+	report.WriteString("# Commit History Review\n\n")
+	report.WriteString(fmt.Sprintf("**Report Generated:** %s\n", time.Now().Format("January 2, 2006 at 15:04:05 MST")))
+	report.WriteString(fmt.Sprintf("**AI Model:** %s\n", viper.GetString("ollama.default_model")))
+	report.WriteString(fmt.Sprintf("**Range:** `%s`\n\n", revRange))
+
+	report.WriteString("## Summary\n\n")
+	report.WriteString(fmt.Sprintf("**Commits Reviewed:** %d (without analyzable changes: %d)\n", summary.Commits, summary.Skipped))
+	report.WriteString(fmt.Sprintf("**Average Score:** %d/100\n", summary.AvgScore))
+	report.WriteString(fmt.Sprintf("**Total Issues:** %d\n", summary.TotalIssues))
+	report.WriteString(fmt.Sprintf("**Regressions:** %d\n\n", summary.Regressions))
+
+	if charted := chartPoints(points); len(charted) > 0 {
+		var labels, scores, issues []string
+		maxValue := 100
+		for _, point := range charted {
+			labels = append(labels, fmt.Sprintf("%q", point.ShortHash))
+			scores = append(scores, fmt.Sprintf("%d", point.Score))
+			issues = append(issues, fmt.Sprintf("%d", point.Issues))
+			if point.Issues > maxValue {
+				maxValue = point.Issues
+			}
+		}
+
+		report.WriteString("## Trend\n\n")
+		report.WriteString("Line: quality score, bars: issues found in the commit.\n\n")
+		report.WriteString("```mermaid\nxychart-beta\n")
+		report.WriteString("    title \"Score and issues per commit\"\n")
+		report.WriteString(fmt.Sprintf("    x-axis [%s]\n", strings.Join(labels, ", ")))
+		report.WriteString(fmt.Sprintf("    y-axis \"Score / Issues\" 0 --> %d\n", maxValue))
+		report.WriteString(fmt.Sprintf("    bar [%s]\n", strings.Join(issues, ", ")))
+		report.WriteString(fmt.Sprintf("    line [%s]\n", strings.Join(scores, ", ")))
+		report.WriteString("```\n\n")
+	}
+
+	report.WriteString("## Timeline\n\n")
+	report.WriteString("| # | Commit | Date | Author | Score | Issues | Critical | High | Medium | Low | Subject |\n")
+	report.WriteString("|---|--------|------|--------|-------|--------|----------|------|--------|-----|---------|\n")
+	for i, point := range points {
+		score := fmt.Sprintf("%d", point.Score)
+		if point.Skipped {
+			score = "—"
+		} else if point.Regression {
+			score += " 📉"
+		}
+		report.WriteString(fmt.Sprintf("| %d | `%s` | %s | %s | %s | %d | %d | %d | %d | %d | %s |\n",
+			i+1, point.ShortHash, point.Date.Format("2006-01-02"), escapeMarkdownCell(point.Author), score, point.Issues,
+			point.BySeverity["critical"], point.BySeverity["high"], point.BySeverity["medium"], point.BySeverity["low"],
+			escapeMarkdownCell(point.Subject)))
+	}
+	report.WriteString("\n")
+
+	if summary.Regressions > 0 {
+		report.WriteString("## Regressions\n\n")
+		for _, point := range points {
+			if !point.Regression {
+				continue
+			}
+			report.WriteString(fmt.Sprintf("### `%s` %s\n\n", point.ShortHash, point.Subject))
+			report.WriteString(fmt.Sprintf("**Author:** %s | **Date:** %s | **Score:** %d/100\n\n", point.Author, point.Date.Format("2006-01-02"), point.Score))
+			for _, result := range point.Results {
+				for _, issue := range result.Issues {
+					location := result.File
+					if issue.Line > 0 {
+						location = fmt.Sprintf("%s:%d", result.File, issue.Line)
+					}
+					report.WriteString(fmt.Sprintf("- **%s** `%s` %s\n", strings.ToUpper(issue.Severity), location, issue.Message))
+				}
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	report.WriteString("---\n\n")
+	report.WriteString("*This report was generated automatically using AI-powered code analysis of each commit in the range.*\n")
+
+	return report.String()
+}
+
+// generateHistoryHTML генерирует HTML отчет с SVG графиком
+func generateHistoryHTML(points []types.CommitTrendPoint, revRange string) string {
+	var report strings.Builder
+	summary := summarizeHistory(points)
+
+	report.WriteString(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>История коммитов</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Arial, sans-serif; margin: 0; padding: 20px; background: #f8f9fa; line-height: 1.6; }
+        .container { max-width: 1200px; margin: 0 auto; background: white; padding: 30px; border-radius: 8px; box-shadow: 0 4px 20px rgba(0,0,0,0.1); }
+        h1 { color: #2c3e50; border-bottom: 3px solid #3498db; padding-bottom: 15px; text-align: center; }
+        h2 { color: #34495e; border-left: 4px solid #3498db; padding-left: 15px; }
+        table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+        th, td { border: 1px solid #dee2e6; padding: 6px 10px; text-align: left; }
+        tr.regression { background: #fdecea; }
+        tr.skipped { color: #95a5a6; }
+        code { font-family: 'Courier New', monospace; }
+        .chart { width: 100%; height: auto; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h1>История коммитов</h1>`)
+
+	report.WriteString(fmt.Sprintf(`
+        <p><strong>Диапазон:</strong> <code>%s</code> | <strong>Модель:</strong> %s | <strong>Создан:</strong> %s</p>
+        <p><strong>Коммитов:</strong> %d (без анализируемых изменений: %d) | <strong>Средняя оценка:</strong> %d/100 | <strong>Проблем:</strong> %d | <strong>Регрессий:</strong> %d</p>`,
+		html.EscapeString(revRange), html.EscapeString(viper.GetString("ollama.default_model")), time.Now().Format("02.01.2006 15:04:05"),
+		summary.Commits, summary.Skipped, summary.AvgScore, summary.TotalIssues, summary.Regressions))
+
+	if charted := chartPoints(points); len(charted) > 0 {
+		report.WriteString(`
+        <h2>Динамика</h2>`)
+		writeTrendSVG(&report, charted)
+	}
+
+	report.WriteString(`
+        <h2>Коммиты</h2>
+        <table>
+            <tr><th>#</th><th>Коммит</th><th>Дата</th><th>Автор</th><th>Оценка</th><th>Проблем</th><th>Критических</th><th>Высоких</th><th>Средних</th><th>Низких</th><th>Сообщение</th></tr>`)
+	for i, point := range points {
+		class, score := "", fmt.Sprintf("%d", point.Score)
+		switch {
+		case point.Skipped:
+			class, score = "skipped", "—"
+		case point.Regression:
+			class, score = "regression", score+" 📉"
+		}
+		report.WriteString(fmt.Sprintf(`
+            <tr class="%s"><td>%d</td><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%s</td></tr>`,
+			class, i+1, point.ShortHash, point.Date.Format("2006-01-02"), html.EscapeString(point.Author), score, point.Issues,
+			point.BySeverity["critical"], point.BySeverity["high"], point.BySeverity["medium"], point.BySeverity["low"],
+			html.EscapeString(point.Subject)))
+	}
+	report.WriteString(`
+        </table>`)
+
+	if summary.Regressions > 0 {
+		report.WriteString(`
+        <h2>Регрессии</h2>`)
+		for _, point := range points {
+			if !point.Regression {
+				continue
+			}
+			report.WriteString(fmt.Sprintf(`
+        <h3><code>%s</code> %s</h3>
+        <p>%s, %s, оценка %d/100</p>
+        <ul>`, point.ShortHash, html.EscapeString(point.Subject), html.EscapeString(point.Author), point.Date.Format("2006-01-02"), point.Score))
+			for _, result := range point.Results {
+				for _, issue := range result.Issues {
+					location := result.File
+					if issue.Line > 0 {
+						location = fmt.Sprintf("%s:%d", result.File, issue.Line)
+					}
+					report.WriteString(fmt.Sprintf(`
+            <li><strong>%s</strong> <code>%s</code> %s</li>`, getSeverityName(issue.Severity), html.EscapeString(location), html.EscapeString(issue.Message)))
+				}
+			}
+			report.WriteString(`
+        </ul>`)
+		}
+	}
+
+	report.WriteString(`
+    </div>
+</body>
+</html>`)
+
+	return report.String()
+}
+
+// writeTrendSVG рисует график: линия - оценка (шкала 0-100 слева), столбцы -
+// количество проблем (шкала справа), регрессии отмечены красными точками
+func writeTrendSVG(report *strings.Builder, points []types.CommitTrendPoint) {
+	const width, height = 900, 300
+	const left, right, top, bottom = 40, 40, 20, 50
+	plotWidth := float64(width - left - right)
+	plotHeight := float64(height - top - bottom)
+
+	maxIssues := 1
+	for _, point := range points {
+		if point.Issues > maxIssues {
+			maxIssues = point.Issues
+		}
+	}
+
+	step := plotWidth / float64(len(points))
+	x := func(i int) float64 { return float64(left) + step*(float64(i)+0.5) }
+	scoreY := func(score int) float64 { return float64(top) + plotHeight*(1-float64(score)/100) }
+	issuesY := func(issues int) float64 { return float64(top) + plotHeight*(1-float64(issues)/float64(maxIssues)) }
+
+	report.WriteString(fmt.Sprintf(`
+        <svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg" font-size="11" font-family="sans-serif">
+            <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#bdc3c7"/>
+            <text x="4" y="%d" fill="#2980b9">100</text>
+            <text x="4" y="%d" fill="#2980b9">0</text>
+            <text x="%d" y="%d" fill="#e67e22">%d</text>`,
+		width, height, left, height-bottom, width-right, height-bottom,
+		top+4, height-bottom, width-right+4, top+4, maxIssues))
+
+	barWidth := step * 0.5
+	var line []string
+	for i, point := range points {
+		barTop := issuesY(point.Issues)
+		report.WriteString(fmt.Sprintf(`
+            <rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#f5b041" opacity="0.7"><title>%s: проблем %d</title></rect>`,
+			x(i)-barWidth/2, barTop, barWidth, float64(height-bottom)-barTop, point.ShortHash, point.Issues))
+		report.WriteString(fmt.Sprintf(`
+            <text x="%.1f" y="%d" text-anchor="end" transform="rotate(-45 %.1f %d)">%s</text>`,
+			x(i), height-bottom+14, x(i), height-bottom+14, point.ShortHash))
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x(i), scoreY(point.Score)))
+	}
+
+	report.WriteString(fmt.Sprintf(`
+            <polyline points="%s" fill="none" stroke="#2980b9" stroke-width="2"/>`, strings.Join(line, " ")))
+
+	for i, point := range points {
+		color := "#2980b9"
+		if point.Regression {
+			color = "#e74c3c"
+		}
+		report.WriteString(fmt.Sprintf(`
+            <circle cx="%.1f" cy="%.1f" r="4" fill="%s"><title>%s %s: оценка %d</title></circle>`,
+			x(i), scoreY(point.Score), color, point.ShortHash, html.EscapeString(point.Subject), point.Score))
+	}
+
+	report.WriteString(`
+        </svg>
+        <p>Линия - оценка качества (шкала слева), столбцы - количество проблем в коммите (шкала справа), красные точки - регрессии.</p>`)
+}
@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"miniReviewer/internal/analyzer"
+	"miniReviewer/internal/types"
+)
+
+// SetModules добавляет в отчет разбивку оценок и проблем по модулям монорепозитория
+func (r *Reporter) SetModules(modules []types.WorkspaceModule) {
+	r.modules = modules
+}
+
+// moduleStatistics возвращает статистику по модулям, если результаты относятся
+// больше чем к одному модулю
+func (r *Reporter) moduleStatistics(results []*types.CodeAnalysisResult) []analyzer.ModuleStatistic {
+	if len(r.modules) == 0 {
+		return nil
+	}
+	statistics := analyzer.GroupByModule(results, r.modules)
+	if len(statistics) < 2 {
+		return nil
+	}
+	return statistics
+}
+
+// writeMarkdownModules выводит оценки и количество проблем по модулям
+func writeMarkdownModules(report *strings.Builder, statistics []analyzer.ModuleStatistic) {
+	report.WriteString("## Findings by Module\n\n")
+	report.WriteString("| Module | Kind | Files | Avg Score | Issues | Critical | High | Medium | Low |\n")
+	report.WriteString("|--------|------|-------|-----------|--------|----------|------|--------|-----|\n")
+
+	for _, stat := range statistics {
+		module := "(outside modules)"
+		if stat.Module != "" {
+			module = fmt.Sprintf("%s (`%s`)", stat.Name, stat.Module)
+		}
+		report.WriteString(fmt.Sprintf("| %s | %s | %d | %d/100 | %d | %d | %d | %d | %d |\n",
+			escapeMarkdownCell(module), stat.Kind, stat.Files, stat.AvgScore, stat.Issues,
+			stat.BySeverity["critical"], stat.BySeverity["high"], stat.BySeverity["medium"], stat.BySeverity["low"]))
+	}
+	report.WriteString("\n")
+}
+
+// writeHTMLModules выводит оценки и количество проблем по модулям
+func writeHTMLModules(report *strings.Builder, statistics []analyzer.ModuleStatistic) {
+	report.WriteString(`
+        <h2>Проблемы по модулям</h2>
+        <table class="author-table">
+            <tr><th>Модуль</th><th>Тип</th><th>Файлов</th><th>Оценка</th><th>Проблем</th><th>Критических</th><th>Высоких</th><th>Средних</th><th>Низких</th></tr>`)
+
+	for _, stat := range statistics {
+		report.WriteString(fmt.Sprintf(`
+            <tr><td>%s</td><td>%s</td><td>%d</td><td>%d/100</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>`,
+			html.EscapeString(analyzer.ModuleLabel(stat)), html.EscapeString(stat.Kind), stat.Files, stat.AvgScore, stat.Issues,
+			stat.BySeverity["critical"], stat.BySeverity["high"], stat.BySeverity["medium"], stat.BySeverity["low"]))
+	}
+
+	report.WriteString(`
+        </table>`)
+}
@@ -0,0 +1,142 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+
+	"miniReviewer/internal/types"
+)
+
+var anchorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SetOverview добавляет в отчет иерархический обзор модулей
+func (r *Reporter) SetOverview(overview *types.ModuleOverview) {
+	r.overview = overview
+}
+
+// moduleAnchor возвращает идентификатор якоря для пакета
+func moduleAnchor(pkg string) string {
+	if pkg == "." {
+		return "module-root"
+	}
+	return "module-" + strings.Trim(anchorPattern.ReplaceAllString(strings.ToLower(pkg), "-"), "-")
+}
+
+// writeMarkdownOverview выводит обзор модулей с оглавлением и ссылками между пакетами
+func writeMarkdownOverview(report *strings.Builder, overview *types.ModuleOverview) {
+	report.WriteString("## Module Overview\n\n")
+	report.WriteString("Summaries built bottom-up: each file is summarized first, then each package from its file summaries.\n\n")
+
+	// Table of contents
+	for _, pkg := range overview.Packages {
+		report.WriteString(fmt.Sprintf("- [%s](#%s) - %s\n", pkg.Package, moduleAnchor(pkg.Package), pkg.Purpose))
+	}
+	report.WriteString("\n")
+
+	for _, pkg := range overview.Packages {
+		report.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n\n", moduleAnchor(pkg.Package)))
+		report.WriteString(fmt.Sprintf("### Package `%s`\n\n", pkg.Package))
+		if pkg.Purpose != "" {
+			report.WriteString(fmt.Sprintf("%s\n\n", pkg.Purpose))
+		}
+
+		if len(pkg.Responsibilities) > 0 {
+			report.WriteString("**Responsibilities:**\n")
+			for _, responsibility := range pkg.Responsibilities {
+				report.WriteString(fmt.Sprintf("- %s\n", responsibility))
+			}
+			report.WriteString("\n")
+		}
+
+		if len(pkg.Dependencies) > 0 {
+			var links []string
+			for _, dependency := range pkg.Dependencies {
+				links = append(links, fmt.Sprintf("[%s](#%s)", dependency, moduleAnchor(dependency)))
+			}
+			report.WriteString(fmt.Sprintf("**Depends on:** %s\n\n", strings.Join(links, ", ")))
+		}
+
+		report.WriteString("| File | Purpose | Exported API |\n")
+		report.WriteString("|------|---------|--------------|\n")
+		for _, file := range pkg.Files {
+			report.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", path.Base(file.File), escapeMarkdownCell(file.Purpose), escapeMarkdownCell(strings.Join(file.Exports, ", "))))
+		}
+		report.WriteString("\n")
+	}
+
+	if overview.Dependencies != "" {
+		report.WriteString("### Import Graph\n\n")
+		report.WriteString("```\n")
+		report.WriteString(overview.Dependencies)
+		report.WriteString("```\n\n")
+	}
+}
+
+// escapeMarkdownCell экранирует текст для ячейки Markdown таблицы
+func escapeMarkdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// writeHTMLOverview выводит обзор модулей с оглавлением и ссылками между пакетами
+func writeHTMLOverview(report *strings.Builder, overview *types.ModuleOverview) {
+	report.WriteString(`
+        <h2>Обзор модулей</h2>
+        <ul class="overview-toc">`)
+
+	for _, pkg := range overview.Packages {
+		report.WriteString(fmt.Sprintf(`
+            <li><a href="#%s">%s</a> - %s</li>`, moduleAnchor(pkg.Package), html.EscapeString(pkg.Package), html.EscapeString(pkg.Purpose)))
+	}
+
+	report.WriteString(`
+        </ul>`)
+
+	for _, pkg := range overview.Packages {
+		report.WriteString(fmt.Sprintf(`
+        <details class="file-result" id="%s">
+            <summary><strong>%s</strong> (файлов: %d)</summary>
+            <p>%s</p>`, moduleAnchor(pkg.Package), html.EscapeString(pkg.Package), len(pkg.Files), html.EscapeString(pkg.Purpose)))
+
+		if len(pkg.Responsibilities) > 0 {
+			report.WriteString(`
+            <ul>`)
+			for _, responsibility := range pkg.Responsibilities {
+				report.WriteString(fmt.Sprintf(`
+                <li>%s</li>`, html.EscapeString(responsibility)))
+			}
+			report.WriteString(`
+            </ul>`)
+		}
+
+		if len(pkg.Dependencies) > 0 {
+			var links []string
+			for _, dependency := range pkg.Dependencies {
+				links = append(links, fmt.Sprintf(`<a href="#%s">%s</a>`, moduleAnchor(dependency), html.EscapeString(dependency)))
+			}
+			report.WriteString(fmt.Sprintf(`
+            <div class="file-stats"><strong>Зависит от:</strong> %s</div>`, strings.Join(links, ", ")))
+		}
+
+		for _, file := range pkg.Files {
+			report.WriteString(fmt.Sprintf(`
+            <div class="issue-details"><strong>%s</strong> - %s`, html.EscapeString(path.Base(file.File)), html.EscapeString(file.Purpose)))
+			if len(file.Exports) > 0 {
+				report.WriteString(fmt.Sprintf(`<br><em>API:</em> %s`, html.EscapeString(strings.Join(file.Exports, ", "))))
+			}
+			report.WriteString(`</div>`)
+		}
+
+		report.WriteString(`
+        </details>`)
+	}
+
+	if overview.Dependencies != "" {
+		report.WriteString(fmt.Sprintf(`
+        <h3>Граф импортов</h3>
+        <pre>%s</pre>`, html.EscapeString(overview.Dependencies)))
+	}
+}
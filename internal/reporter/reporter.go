@@ -3,11 +3,13 @@ package reporter
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"miniReviewer/internal/analyzer"
 	"miniReviewer/internal/types"
 
 	"github.com/spf13/viper"
@@ -15,7 +17,10 @@ import (
 
 // Reporter генератор отчетов
 type Reporter struct {
-	options *types.ReportOptions
+	options  *types.ReportOptions
+	overview *types.ModuleOverview
+	skipped  []types.SkippedFile
+	modules  []types.WorkspaceModule
 }
 
 // NewReporter создает новый генератор отчетов
@@ -45,15 +50,22 @@ func (r *Reporter) generateJSONReport(results []*types.CodeAnalysisResult) (stri
 		GeneratedAt time.Time                   `json:"generated_at"`
 		Model       string                      `json:"model"`
 		Results     []*types.CodeAnalysisResult `json:"results"`
+		Overview    *types.ModuleOverview       `json:"overview,omitempty"`
+		Skipped     []types.SkippedFile         `json:"skipped,omitempty"`
 		Summary     struct {
-			TotalFiles  int `json:"total_files"`
-			TotalIssues int `json:"total_issues"`
-			AvgScore    int `json:"avg_score"`
+			TotalFiles          int                        `json:"total_files"`
+			TotalIssues         int                        `json:"total_issues"`
+			AvgScore            int                        `json:"avg_score"`
+			DuplicatesCollapsed int                        `json:"duplicates_collapsed"`
+			ByAuthor            []analyzer.AuthorStatistic `json:"by_author,omitempty"`
+			ByModule            []analyzer.ModuleStatistic `json:"by_module,omitempty"`
 		} `json:"summary"`
 	}{
 		GeneratedAt: time.Now(),
 		Model:       viper.GetString("ollama.default_model"),
 		Results:     results,
+		Overview:    r.overview,
+		Skipped:     r.skipped,
 	}
 
 	// Вычисляем статистику
@@ -62,6 +74,7 @@ func (r *Reporter) generateJSONReport(results []*types.CodeAnalysisResult) (stri
 	for _, result := range results {
 		totalIssues += len(result.Issues)
 		totalScore += result.Score
+		report.Summary.DuplicatesCollapsed += result.DuplicatesCollapsed
 	}
 
 	if len(results) > 0 {
@@ -70,6 +83,11 @@ func (r *Reporter) generateJSONReport(results []*types.CodeAnalysisResult) (stri
 		report.Summary.AvgScore = totalScore / len(results)
 	}
 
+	report.Summary.ByModule = r.moduleStatistics(results)
+	if hasAuthorInfo(results) {
+		report.Summary.ByAuthor = analyzer.GroupByAuthor(results)
+	}
+
 	jsonData, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("ошибка маршалинга JSON: %v", err)
@@ -97,10 +115,12 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 	var highIssues int
 	var mediumIssues int
 	var lowIssues int
+	var duplicatesCollapsed int
 
 	for _, result := range results {
 		totalIssues += len(result.Issues)
 		totalScore += result.Score
+		duplicatesCollapsed += result.DuplicatesCollapsed
 
 		for _, issue := range result.Issues {
 			switch issue.Severity {
@@ -124,7 +144,11 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 		report.WriteString(fmt.Sprintf("**Critical Issues:** %d\n", criticalIssues))
 		report.WriteString(fmt.Sprintf("**High Priority Issues:** %d\n", highIssues))
 		report.WriteString(fmt.Sprintf("**Medium Priority Issues:** %d\n", mediumIssues))
-		report.WriteString(fmt.Sprintf("**Low Priority Issues:** %d\n\n", lowIssues))
+		report.WriteString(fmt.Sprintf("**Low Priority Issues:** %d\n", lowIssues))
+		if duplicatesCollapsed > 0 {
+			report.WriteString(fmt.Sprintf("**Duplicates Collapsed:** %d (same issue reported by several analyzers)\n", duplicatesCollapsed))
+		}
+		report.WriteString("\n")
 
 		// Risk Assessment
 		if criticalIssues > 0 || highIssues > 0 {
@@ -136,6 +160,22 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 		}
 	}
 
+	if statistics := r.moduleStatistics(results); statistics != nil {
+		writeMarkdownModules(&report, statistics)
+	}
+
+	if hasAuthorInfo(results) {
+		writeMarkdownAuthors(&report, results)
+	}
+
+	if r.overview != nil {
+		writeMarkdownOverview(&report, r.overview)
+	}
+
+	if len(r.skipped) > 0 {
+		writeMarkdownSkipped(&report, r.skipped)
+	}
+
 	// Detailed Analysis
 	report.WriteString("## Detailed Analysis\n\n")
 
@@ -159,7 +199,22 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 			report.WriteString("\n")
 		}
 
-		if len(result.Issues) > 0 {
+		if analyzer.HasFunctionInfo(result.Issues) {
+			// Function-level analysis: group issues by function
+			order, groups := analyzer.GroupIssuesByFunction(result.Issues)
+			for k, function := range order {
+				issues := groups[function]
+				report.WriteString(fmt.Sprintf("#### Function `%s` (%d found)\n\n", getFunctionLabel(function), len(issues)))
+
+				for j, issue := range issues {
+					writeMarkdownIssue(&report, result, fmt.Sprintf("%d.%d.%d", i+1, k+1, j+1), issue)
+
+					if j < len(issues)-1 {
+						report.WriteString("---\n\n")
+					}
+				}
+			}
+		} else if len(result.Issues) > 0 {
 			// Group issues by type for better organization
 			issuesByType := make(map[string][]types.Issue)
 			for _, issue := range result.Issues {
@@ -174,46 +229,7 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 					report.WriteString(fmt.Sprintf("#### %s Issues (%d found)\n\n", strings.Title(issueType), len(issues)))
 
 					for j, issue := range issues {
-						report.WriteString(fmt.Sprintf("**Issue %d.%d:** %s\n\n", i+1, j+1, issue.Message))
-
-						// Issue Details Table
-						report.WriteString("| Property | Value |\n")
-						report.WriteString("|----------|-------|\n")
-						report.WriteString(fmt.Sprintf("| **Severity** | %s |\n", strings.ToUpper(issue.Severity)))
-						report.WriteString(fmt.Sprintf("| **Category** | %s |\n", strings.Title(issue.Type)))
-						if issue.Line > 0 {
-							report.WriteString(fmt.Sprintf("| **Line Number** | %d |\n", issue.Line))
-						}
-						report.WriteString(fmt.Sprintf("| **Priority** | %s |\n", getPriorityLevel(issue.Severity)))
-						report.WriteString("\n")
-
-						if issue.Suggestion != "" {
-							report.WriteString("**Recommended Solution:**\n")
-							report.WriteString(fmt.Sprintf("> %s\n\n", issue.Suggestion))
-						}
-
-						if issue.Reasoning != "" {
-							report.WriteString("**Technical Analysis:**\n")
-							report.WriteString(fmt.Sprintf("> %s\n\n", issue.Reasoning))
-						}
-
-						// Impact Assessment
-						report.WriteString("**Impact Assessment:**\n")
-						report.WriteString(fmt.Sprintf("- **Risk Level:** %s\n", getRiskLevel(issue.Severity)))
-						report.WriteString(fmt.Sprintf("- **Maintenance Impact:** %s\n", getMaintenanceImpact(issue.Type)))
-						report.WriteString(fmt.Sprintf("- **Security Implications:** %s\n", getSecurityImplications(issue.Type, issue.Severity)))
-						report.WriteString("\n")
-
-						// Code Example (if applicable)
-						if issue.Line > 0 {
-							report.WriteString("**Code Location:**\n")
-							report.WriteString(fmt.Sprintf("```%s\n// Line %d: %s\n```\n\n", getFileExtension(result.File), issue.Line, issue.Message))
-						}
-
-						// Best Practices Reference
-						report.WriteString("**Best Practices Reference:**\n")
-						report.WriteString(getBestPractices(issue.Type))
-						report.WriteString("\n")
+						writeMarkdownIssue(&report, result, fmt.Sprintf("%d.%d", i+1, j+1), issue)
 
 						if j < len(issues)-1 {
 							report.WriteString("---\n\n")
@@ -314,6 +330,72 @@ func (r *Reporter) generateMarkdownReport(results []*types.CodeAnalysisResult) (
 	return report.String(), nil
 }
 
+// writeMarkdownIssue выводит одну проблему в Markdown отчет
+func writeMarkdownIssue(report *strings.Builder, result *types.CodeAnalysisResult, number string, issue types.Issue) {
+	report.WriteString(fmt.Sprintf("**Issue %s:** %s\n\n", number, issue.Message))
+
+	// Issue Details Table
+	report.WriteString("| Property | Value |\n")
+	report.WriteString("|----------|-------|\n")
+	report.WriteString(fmt.Sprintf("| **Severity** | %s |\n", strings.ToUpper(issue.Severity)))
+	report.WriteString(fmt.Sprintf("| **Category** | %s |\n", strings.Title(issue.Type)))
+	if len(issue.Categories) > 1 {
+		report.WriteString(fmt.Sprintf("| **Reported By** | %s |\n", strings.Join(issue.Categories, ", ")))
+	}
+	if issue.Rule != "" {
+		report.WriteString(fmt.Sprintf("| **Rule** | `%s` (%s) |\n", issue.Rule, strings.Join(issue.Categories, ", ")))
+	}
+	if issue.Group != "" {
+		report.WriteString(fmt.Sprintf("| **Group** | %s |\n", issue.Group))
+	}
+	if issue.Line > 0 {
+		report.WriteString(fmt.Sprintf("| **Line Number** | %d |\n", issue.Line))
+	}
+	if issue.Blame != nil {
+		report.WriteString(fmt.Sprintf("| **Author** | %s |\n", escapeMarkdownCell(blameLabel(issue.Blame))))
+	}
+	report.WriteString(fmt.Sprintf("| **Priority** | %s |\n", getPriorityLevel(issue.Severity)))
+	if issue.CWE != "" {
+		report.WriteString(fmt.Sprintf("| **CWE** | [%s](%s) %s |\n", issue.CWE, getCWEURL(issue.CWE), analyzer.CWEName(issue.CWE)))
+	}
+	if issue.OWASP != "" {
+		report.WriteString(fmt.Sprintf("| **OWASP Top 10** | %s %s |\n", issue.OWASP, analyzer.OWASPCategories[issue.OWASP]))
+	}
+	if issue.Fingerprint != "" {
+		report.WriteString(fmt.Sprintf("| **Fingerprint** | `%s` |\n", issue.Fingerprint))
+	}
+	report.WriteString("\n")
+
+	if issue.Suggestion != "" {
+		report.WriteString("**Recommended Solution:**\n")
+		report.WriteString(fmt.Sprintf("> %s\n\n", issue.Suggestion))
+	}
+
+	if issue.Reasoning != "" {
+		report.WriteString("**Technical Analysis:**\n")
+		report.WriteString(fmt.Sprintf("> %s\n\n", issue.Reasoning))
+	}
+
+	// Impact Assessment
+	report.WriteString("**Impact Assessment:**\n")
+	report.WriteString(fmt.Sprintf("- **Risk Level:** %s\n", getRiskLevel(issue.Severity)))
+	report.WriteString(fmt.Sprintf("- **Maintenance Impact:** %s\n", getMaintenanceImpact(issue.Type)))
+	report.WriteString(fmt.Sprintf("- **Security Implications:** %s\n", getSecurityImplications(issue.Type, issue.Severity)))
+	report.WriteString("\n")
+
+	// Code Example (if applicable)
+	if issue.Line > 0 {
+		report.WriteString("**Code Location:**\n")
+		report.WriteString(fmt.Sprintf("```%s\n// Line %d: %s\n```\n\n", getFileExtension(result.File), issue.Line, issue.Message))
+	}
+
+	// Best Practices Reference
+	report.WriteString("**Best Practices Reference:**\n")
+	report.WriteString(getBestPractices(issue.Type))
+	report.WriteString("\n")
+
+}
+
 // generateHTMLReport генерирует HTML отчет
 func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (string, error) {
 	var report strings.Builder
@@ -460,6 +542,15 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
             color: #92400e;
             font-size: 0.9em;
         }
+        .author-table {
+            border-collapse: collapse;
+            margin: 15px 0;
+        }
+        .author-table th, .author-table td {
+            border: 1px solid #dee2e6;
+            padding: 6px 12px;
+            text-align: left;
+        }
         .type-header {
             background: #34495e;
             color: white;
@@ -505,10 +596,12 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
 	var highIssues int
 	var mediumIssues int
 	var lowIssues int
+	var duplicatesCollapsed int
 
 	for _, result := range results {
 		totalIssues += len(result.Issues)
 		totalScore += result.Score
+		duplicatesCollapsed += result.DuplicatesCollapsed
 
 		for _, issue := range result.Issues {
 			switch issue.Severity {
@@ -540,7 +633,27 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
                 <div class="stat-number">%d</div>
                 <div class="stat-label">Файлов</div>
             </div>
-        </div>`, avgScore, totalIssues, len(results)))
+            <div class="stat-card">
+                <div class="stat-number">%d</div>
+                <div class="stat-label">Объединено дубликатов</div>
+            </div>
+        </div>`, avgScore, totalIssues, len(results), duplicatesCollapsed))
+	}
+
+	if statistics := r.moduleStatistics(results); statistics != nil {
+		writeHTMLModules(&report, statistics)
+	}
+
+	if hasAuthorInfo(results) {
+		writeHTMLAuthors(&report, results)
+	}
+
+	if r.overview != nil {
+		writeHTMLOverview(&report, r.overview)
+	}
+
+	if len(r.skipped) > 0 {
+		writeHTMLSkipped(&report, r.skipped)
 	}
 
 	// Issues by File
@@ -555,7 +668,18 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
                 <strong>Оценка:</strong> %d/100 | <strong>Проблем:</strong> %d
             </div>`, result.File, result.Score, len(result.Issues)))
 
-		if len(result.Issues) > 0 {
+		if analyzer.HasFunctionInfo(result.Issues) {
+			// Group issues by function
+			order, groups := analyzer.GroupIssuesByFunction(result.Issues)
+			for _, function := range order {
+				report.WriteString(fmt.Sprintf(`
+            <div class="type-header">ƒ %s (%d)</div>`, getFunctionLabelName(function), len(groups[function])))
+
+				for _, issue := range groups[function] {
+					writeHTMLIssue(&report, issue)
+				}
+			}
+		} else if len(result.Issues) > 0 {
 			// Group issues by type
 			issuesByType := make(map[string][]types.Issue)
 			for _, issue := range result.Issues {
@@ -571,28 +695,7 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
             <div class="type-header">%s (%d)</div>`, getIssueTypeName(issueType), len(issues)))
 
 					for _, issue := range issues {
-						report.WriteString(fmt.Sprintf(`
-            <div class="issue %s">
-                <div class="severity %s">%s</div>
-                <div class="issue-message">%s</div>`, issue.Severity, issue.Severity, getSeverityName(issue.Severity), issue.Message))
-
-						if issue.Line > 0 {
-							report.WriteString(fmt.Sprintf(`
-                <div class="line-info">Строка %d</div>`, issue.Line))
-						}
-
-						if issue.Suggestion != "" {
-							report.WriteString(fmt.Sprintf(`
-                <div class="issue-details"><strong>Решение:</strong> %s</div>`, issue.Suggestion))
-						}
-
-						if issue.Reasoning != "" {
-							report.WriteString(fmt.Sprintf(`
-                <div class="issue-details"><strong>Анализ:</strong> %s</div>`, issue.Reasoning))
-						}
-
-						report.WriteString(`
-            </div>`)
+						writeHTMLIssue(&report, issue)
 					}
 				}
 			}
@@ -613,6 +716,71 @@ func (r *Reporter) generateHTMLReport(results []*types.CodeAnalysisResult) (stri
 	return report.String(), nil
 }
 
+// writeHTMLIssue выводит одну проблему в HTML отчет
+func writeHTMLIssue(report *strings.Builder, issue types.Issue) {
+	report.WriteString(fmt.Sprintf(`
+            <div class="issue %s">
+                <div class="severity %s">%s</div>
+                <div class="issue-message">%s</div>`, issue.Severity, issue.Severity, getSeverityName(issue.Severity), issue.Message))
+
+	if issue.Line > 0 {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info">Строка %d</div>`, issue.Line))
+	}
+
+	if issue.Blame != nil {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info">Автор: %s</div>`, html.EscapeString(blameLabel(issue.Blame))))
+	}
+
+	if len(issue.Categories) > 1 {
+		var names []string
+		for _, category := range issue.Categories {
+			names = append(names, getIssueTypeName(category))
+		}
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info">Найдено анализаторами: %s</div>`, strings.Join(names, ", ")))
+	}
+
+	if issue.Rule != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info">Правило %s (%s)</div>`, html.EscapeString(issue.Rule), html.EscapeString(strings.Join(issue.Categories, ", "))))
+	}
+
+	if issue.Group != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info">Группа: %s</div>`, html.EscapeString(issue.Group)))
+	}
+
+	if issue.CWE != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info"><a href="%s">%s</a> %s · OWASP %s</div>`, getCWEURL(issue.CWE), issue.CWE, analyzer.CWEName(issue.CWE), issue.OWASP))
+	}
+
+	if issue.Suggestion != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="issue-details"><strong>Решение:</strong> %s</div>`, issue.Suggestion))
+	}
+
+	if issue.Reasoning != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="issue-details"><strong>Анализ:</strong> %s</div>`, issue.Reasoning))
+	}
+
+	if issue.Fingerprint != "" {
+		report.WriteString(fmt.Sprintf(`
+                <div class="line-info" title="Отпечаток проблемы">ID %s</div>`, issue.Fingerprint))
+	}
+
+	report.WriteString(`
+            </div>`)
+}
+
+// getCWEURL возвращает ссылку на описание CWE в каталоге MITRE
+func getCWEURL(cwe string) string {
+	return fmt.Sprintf("https://cwe.mitre.org/data/definitions/%s.html", strings.TrimPrefix(cwe, "CWE-"))
+}
+
 // SaveReport сохраняет отчет в файл
 func (r *Reporter) SaveReport(report string, filename string) error {
 	return os.WriteFile(filename, []byte(report), 0644)
@@ -695,6 +863,13 @@ func getBestPractices(issueType string) string {
 	}
 }
 
+func getFunctionLabel(function string) string {
+	if function == "" {
+		return "package level"
+	}
+	return function
+}
+
 func getFileExtension(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
@@ -864,6 +1039,13 @@ func getBestPracticesName(issueType string) string {
 	return "• Следуйте общепринятым стандартам разработки\n• Используйте современные инструменты и практики\n• Регулярно проводите рефакторинг"
 }
 
+func getFunctionLabelName(function string) string {
+	if function == "" {
+		return "уровень пакета"
+	}
+	return function
+}
+
 func getDebtClassificationName(totalDebt int) string {
 	if totalDebt >= 50 {
 		return "Критический - требует немедленного внимания"
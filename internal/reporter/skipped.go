@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"miniReviewer/internal/filesystem"
+	"miniReviewer/internal/types"
+)
+
+// SetSkipped добавляет в отчет файлы, пропущенные при сканировании
+func (r *Reporter) SetSkipped(skipped []types.SkippedFile) {
+	r.skipped = skipped
+}
+
+// writeMarkdownSkipped выводит пропущенные файлы с причинами
+func writeMarkdownSkipped(report *strings.Builder, skipped []types.SkippedFile) {
+	report.WriteString("## Skipped Files\n\n")
+	report.WriteString("Files not sent for analysis: too large, generated, lockfiles, minified, vendored or binary.\n\n")
+	report.WriteString("| File | Reason | Detail |\n")
+	report.WriteString("|------|--------|--------|\n")
+
+	for _, file := range skipped {
+		report.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+			escapeMarkdownCell(file.File), file.Reason, escapeMarkdownCell(file.Detail)))
+	}
+	report.WriteString("\n")
+}
+
+// writeHTMLSkipped выводит пропущенные файлы с причинами
+func writeHTMLSkipped(report *strings.Builder, skipped []types.SkippedFile) {
+	report.WriteString(`
+        <h2>Пропущенные файлы</h2>
+        <table class="author-table">
+            <tr><th>Файл</th><th>Причина</th><th>Подробности</th></tr>`)
+
+	for _, file := range skipped {
+		report.WriteString(fmt.Sprintf(`
+            <tr><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			html.EscapeString(file.File), html.EscapeString(filesystem.SkipReasonName(file.Reason)), html.EscapeString(file.Detail)))
+	}
+
+	report.WriteString(`
+        </table>`)
+}
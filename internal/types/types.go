@@ -8,6 +8,10 @@ type CodeAnalysisResult struct {
 	Issues    []Issue   `json:"issues"`
 	Score     int       `json:"score"`
 	Timestamp time.Time `json:"timestamp"`
+	FileHash  string    `json:"file_hash,omitempty"` // SHA-256 содержимого файла на момент анализа
+	Module    string    `json:"module,omitempty"`    // путь модуля монорепозитория, к которому относится файл
+
+	DuplicatesCollapsed int `json:"duplicates_collapsed,omitempty"` // Количество объединенных дубликатов проблем
 }
 
 // Issue проблема в коде
@@ -19,7 +23,25 @@ type Issue struct {
 	Line        int    `json:"line,omitempty"`
 	Column      int    `json:"column,omitempty"`
 	File        string `json:"file,omitempty"`
-	Reasoning   string `json:"reasoning,omitempty"` // Размышления модели о проблеме
+	Function    string `json:"function,omitempty"`    // Функция или объявление, в котором найдена проблема
+	CWE         string `json:"cwe,omitempty"`         // Идентификатор CWE для проблем безопасности
+	OWASP       string `json:"owasp,omitempty"`       // Категория OWASP Top 10 для проблем безопасности
+	Reasoning   string `json:"reasoning,omitempty"`   // Размышления модели о проблеме
+	Fingerprint string `json:"fingerprint,omitempty"` // Стабильный идентификатор проблемы между запусками
+	Rule        string `json:"rule,omitempty"`        // Правило линтера, сообщившего о проблеме (SA4006, no-unused-vars)
+	Group       string `json:"group,omitempty"`       // Группа связанных проблем, выделенная AI
+
+	Categories []string `json:"categories,omitempty"` // Категории анализаторов, сообщивших о проблеме (после дедупликации)
+
+	Blame *BlameInfo `json:"blame,omitempty"` // Автор последнего изменения строки (git blame)
+}
+
+// BlameInfo автор и коммит последнего изменения строки с проблемой
+type BlameInfo struct {
+	Author string    `json:"author"`
+	Email  string    `json:"email,omitempty"`
+	Commit string    `json:"commit,omitempty"` // Пустой для незакоммиченных изменений
+	Date   time.Time `json:"date"`
 }
 
 // AnalysisOptions опции для анализа
@@ -58,3 +80,99 @@ type ReportOptions struct {
 	IncludeSeverityLevels bool   `json:"include_severity_levels"`
 	IncludeRecommendations bool  `json:"include_recommendations"`
 }
+
+// FileSummary краткое описание файла для иерархического обзора проекта
+type FileSummary struct {
+	File         string   `json:"file"`
+	Purpose      string   `json:"purpose"`
+	Exports      []string `json:"exports,omitempty"`      // Экспортируемый API
+	Dependencies []string `json:"dependencies,omitempty"` // Импорты файла
+}
+
+// PackageSummary описание пакета (каталога), собранное из описаний его файлов
+type PackageSummary struct {
+	Package          string        `json:"package"` // Каталог относительно корня проекта
+	Purpose          string        `json:"purpose"`
+	Responsibilities []string      `json:"responsibilities,omitempty"`
+	Dependencies     []string      `json:"dependencies,omitempty"` // Пакеты проекта, от которых зависит пакет
+	Files            []FileSummary `json:"files"`
+}
+
+// ModuleOverview иерархический обзор проекта: пакеты, их файлы и граф зависимостей
+type ModuleOverview struct {
+	Root         string           `json:"root"`
+	Packages     []PackageSummary `json:"packages"`
+	Dependencies string           `json:"dependencies,omitempty"` // Текстовое описание графа импортов
+}
+
+// CommitReview результат проверки одного коммита
+type CommitReview struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+	Files   int       `json:"files"`
+	Changed int       `json:"changed_lines"`
+	Issues  []Issue   `json:"issues"`
+}
+
+// CommitTrendPoint результат анализа одного коммита в истории изменений
+type CommitTrendPoint struct {
+	Hash       string                `json:"hash"`
+	ShortHash  string                `json:"short_hash"`
+	Author     string                `json:"author"`
+	Date       time.Time             `json:"date"`
+	Subject    string                `json:"subject"`
+	Files      int                   `json:"files"`   // проанализировано файлов
+	Skipped    bool                  `json:"skipped"` // в коммите нет анализируемых изменений
+	Score      int                   `json:"score"`   // средняя оценка файлов коммита
+	Issues     int                   `json:"issues"`
+	BySeverity map[string]int        `json:"by_severity"`
+	Regression bool                  `json:"regression"` // коммит ухудшил качество
+	Results    []*CodeAnalysisResult `json:"results,omitempty"`
+}
+
+// Hotspot файл, который часто меняется и сложно устроен: изменения в нем
+// чаще приводят к ошибкам
+type Hotspot struct {
+	File        string              `json:"file"`
+	Commits     int                 `json:"commits"`     // коммитов, изменивших файл за период
+	Authors     int                 `json:"authors"`     // разных авторов изменений
+	ChurnLines  int                 `json:"churn_lines"` // добавлено и удалено строк за период
+	Lines       int                 `json:"lines"`       // строк в файле
+	Complexity  int                 `json:"complexity"`  // точек ветвления в файле
+	LastChanged time.Time           `json:"last_changed"`
+	Risk        int                 `json:"risk"` // 0-100
+	Analysis    *CodeAnalysisResult `json:"analysis,omitempty"`
+}
+
+// WorkspaceModule модуль монорепозитория: директория с go.mod, package.json,
+// pyproject.toml или Cargo.toml
+type WorkspaceModule struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`                // относительно корня проекта, "." - корень
+	Kind      string `json:"kind"`                // go, node, python, rust
+	Workspace string `json:"workspace,omitempty"` // файл рабочего пространства, объявивший модуль
+}
+
+// SkippedFile файл, пропущенный при сканировании: слишком большой, сгенерированный,
+// lockfile, минифицированный, сторонний (vendored) или бинарный
+type SkippedFile struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`           // too_large, generated, lockfile, minified, vendored, binary
+	Detail string `json:"detail,omitempty"` // маркер, размер или директория
+}
+
+// ChangeDescription сгенерированные по diff сообщение коммита и описание PR
+type ChangeDescription struct {
+	Type         string   `json:"type"` // тип Conventional Commits
+	Scope        string   `json:"scope,omitempty"`
+	Subject      string   `json:"subject"`
+	Body         string   `json:"body,omitempty"`
+	Breaking     bool     `json:"breaking,omitempty"`
+	Title        string   `json:"title"` // заголовок PR
+	Summary      string   `json:"summary"`
+	RiskAreas    []string `json:"risk_areas"`
+	TestingNotes []string `json:"testing_notes"`
+	Commits      []string `json:"commits,omitempty"` // коммиты описываемых изменений
+}
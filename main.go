@@ -15,6 +15,8 @@ var (
 	cfgFile string
 	verbose bool
 	model   string
+	repo    string
+	modules []string
 )
 
 func main() {
@@ -24,16 +26,24 @@ func main() {
 		Long: `miniReviewer - это консольный помощник для проведения code review 
 		с использованием AI (Ollama). Он анализирует код, предлагает улучшения 
 		и генерирует подробные отчеты.`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRun: func(command *cobra.Command, args []string) {
+			// Переходим в корень репозитория до чтения конфигурации из него
+			if err := cmd.UseRepository(command, repo); err != nil {
+				fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+				os.Exit(1)
+			}
 			// Инициализация конфигурации
 			initConfig()
 			// Применяем только явно переданные пользователем флаги
-			if cmd.Flags().Changed("model") {
+			if command.Flags().Changed("model") {
 				viper.Set("ollama.default_model", model)
 			}
-			if cmd.Flags().Changed("verbose") {
+			if command.Flags().Changed("verbose") {
 				viper.Set("verbose", verbose)
 			}
+			if command.Flags().Changed("module") {
+				viper.Set("module", modules)
+			}
 		},
 	}
 
@@ -41,6 +51,8 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "конфигурационный файл (по умолчанию .miniReviewer.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "подробный вывод")
 	rootCmd.PersistentFlags().StringVar(&model, "model", "gemma3n:e4b", "модель Ollama для использования")
+	rootCmd.PersistentFlags().StringVar(&repo, "repo", "", "путь к репозиторию для анализа (по умолчанию репозиторий текущей директории)")
+	rootCmd.PersistentFlags().StringSliceVar(&modules, "module", nil, "анализировать только модули монорепозитория (имя или путь, можно несколько)")
 
 	// Команды
 	rootCmd.AddCommand(cmd.AnalyzeCmd())
@@ -48,6 +60,13 @@ func main() {
 	rootCmd.AddCommand(cmd.SecurityCmd())
 	rootCmd.AddCommand(cmd.ArchitectureCmd())
 	rootCmd.AddCommand(cmd.ReportCmd())
+	rootCmd.AddCommand(cmd.CommitsCmd())
+	rootCmd.AddCommand(cmd.DescribeCmd())
+	rootCmd.AddCommand(cmd.HistoryCmd())
+	rootCmd.AddCommand(cmd.HotspotsCmd())
+	rootCmd.AddCommand(cmd.ImportLintCmd())
+	rootCmd.AddCommand(cmd.FixCmd())
+	rootCmd.AddCommand(cmd.HookCmd())
 	rootCmd.AddCommand(cmd.VersionCmd())
 	rootCmd.AddCommand(cmd.TestOllamaCmd())
 
@@ -73,11 +92,21 @@ func initConfig() {
 	viper.SetDefault("ollama.max_tokens", 4000)
 	viper.SetDefault("ollama.temperature", 0.1)
 	viper.SetDefault("ollama.timeout", "300s")
+	viper.SetDefault("ollama.embedding_model", "nomic-embed-text")
 
 	viper.SetDefault("analysis.languages", []string{"go", "python", "javascript", "typescript", "java", "c++"})
 	viper.SetDefault("analysis.ignore_patterns", []string{"vendor/*", "node_modules/*", "*.min.js", "*.min.css"})
 	viper.SetDefault("analysis.max_file_size", "1MB")
 
+	viper.SetDefault("workspace.enabled", true)
+
+	viper.SetDefault("diff.expand_hunks", true)
+	viper.SetDefault("diff.context_lines", 10)
+	viper.SetDefault("diff.max_function_lines", 200)
+
+	viper.SetDefault("context.enabled", true)
+	viper.SetDefault("context.max_tokens", 1500)
+
 	viper.SetDefault("quality.max_complexity", 10)
 	viper.SetDefault("quality.max_function_length", 50)
 	viper.SetDefault("quality.max_file_length", 1000)
@@ -87,6 +116,51 @@ func initConfig() {
 	viper.SetDefault("security.check_dependencies", true)
 	viper.SetDefault("security.ai_vulnerability_scan", true)
 
+	viper.SetDefault("fix.context_lines", 5)
+
+	viper.SetDefault("dedup.enabled", true)
+	viper.SetDefault("dedup.similarity_threshold", 0.5)
+	viper.SetDefault("dedup.line_tolerance", 2)
+	viper.SetDefault("dedup.use_embeddings", false)
+	viper.SetDefault("dedup.embedding_threshold", 0.85)
+
+	viper.SetDefault("lint.ai_triage", true)
+	viper.SetDefault("lint.context_lines", 3)
+	viper.SetDefault("lint.batch_size", 20)
+
+	viper.SetDefault("summary.cache_file", ".miniReviewer-cache/summaries.json")
+	viper.SetDefault("summary.cache_ttl", "168h")
+
+	viper.SetDefault("git.enable_commit_analysis", true)
+	viper.SetDefault("git.enable_branch_analysis", true)
+	viper.SetDefault("git.max_commit_history", 100)
+	viper.SetDefault("git.ignore_merge_commits", false)
+	viper.SetDefault("git.enable_blame", true)
+
+	viper.SetDefault("history.parallel", 1)
+	viper.SetDefault("history.regression_score_drop", 10)
+
+	viper.SetDefault("hotspots.since", "6 months ago")
+	viper.SetDefault("hotspots.top", 10)
+	viper.SetDefault("hotspots.analyze", false)
+
+	viper.SetDefault("hooks.install", []string{"pre-commit", "pre-push"})
+	viper.SetDefault("hooks.fail_on", "high")
+	viper.SetDefault("hooks.time_budget", "5m")
+
+	viper.SetDefault("commits.conventional_types", []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"})
+	viper.SetDefault("commits.max_subject_length", 72)
+	viper.SetDefault("commits.max_changed_lines", 500)
+	viper.SetDefault("commits.max_files", 20)
+	viper.SetDefault("commits.max_areas", 3)
+	viper.SetDefault("commits.ai_message_check", false)
+
+	viper.SetDefault("describe.language", "English")
+	viper.SetDefault("describe.max_diff_size", 12000)
+	viper.SetDefault("describe.format", "markdown")
+
+	viper.SetDefault("performance.enable_caching", true)
+
 	viper.SetDefault("reports.format", "html")
 	viper.SetDefault("reports.include_metrics", true)
 	viper.SetDefault("reports.include_ai_suggestions", true)